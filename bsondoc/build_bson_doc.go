@@ -81,6 +81,18 @@ func build(
 			nodePath := schema.GetPathForField(bsonNode.Key, parent)
 			visitedSchemaNodes = append(visitedSchemaNodes, nodePath)
 
+			if translateTo == TranslateToEnumMongo {
+				if err := validateNodeValue(bsonNode.Value, schemaNodes, nodePath); err != nil {
+					return err
+				}
+			}
+
+			if translateTo == TranslateToEnumEntityModel {
+				if bsonNode.Value, err = runFieldOptionHooks(ctx, bsonNode.Value, schemaNodes, nodePath, translateTo); err != nil {
+					return err
+				}
+			}
+
 			convertedValue, err := getConvertedValueForNode(ctx, bsonNode.Value, schemaNodes, nodePath, translateTo)
 			if err != nil {
 				return err
@@ -88,6 +100,12 @@ func build(
 
 			bsonNode.Value = convertedValue
 
+			if translateTo == TranslateToEnumMongo {
+				if bsonNode.Value, err = runFieldOptionHooks(ctx, bsonNode.Value, schemaNodes, nodePath, translateTo); err != nil {
+					return err
+				}
+			}
+
 			(*bsonElem)[bsonIdx] = bsonNode
 		}
 
@@ -236,6 +254,13 @@ func addMissingNodes(
 			continue
 		}
 
+		// skip the node if it is deselected by default while translating to entity model as it is excluded
+		// from the found docs using a projection.
+		isSelected := missingSchemaNode.Props.Options.Select
+		if translateTo == TranslateToEnumEntityModel && isSelected != nil && !*isSelected {
+			continue
+		}
+
 		// skip the node if it is not required and has no default value
 		if !missingSchemaNode.Props.Options.Required && missingSchemaNode.Props.Options.Default == nil {
 			continue
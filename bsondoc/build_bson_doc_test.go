@@ -2,12 +2,17 @@ package bsondoc_test
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/Lyearn/mgod/bsondoc"
 	"github.com/Lyearn/mgod/dateformatter"
+	"github.com/Lyearn/mgod/errors"
 	"github.com/Lyearn/mgod/schema"
+	"github.com/Lyearn/mgod/schema/fieldopt"
 	"github.com/Lyearn/mgod/schema/schemaopt"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
@@ -812,3 +817,173 @@ func (s *BuildBSONDocSuite) TestBuildBSONDocWithoutID() {
 	s.True(doc[1].Value.(primitive.D)[1].Key == "_id")
 	s.True(doc[1].Value.(primitive.D)[1].Value.(primitive.ObjectID).Hex() != "")
 }
+
+func (s *BuildBSONDocSuite) TestBuildBSONDocWithValidationRules() {
+	type UserProfile struct {
+		Bio string `bson:"bio" mgoValidate:"maxLen=10"`
+	}
+
+	type User struct {
+		ID      string      `bson:"_id" mgoType:"id"`
+		Name    string      `bson:"name" mgoValidate:"minLen=2,maxLen=20"`
+		Age     int         `bson:"age" mgoValidate:"min=0,max=120"`
+		Email   string      `bson:"email" mgoValidate:"email"`
+		Role    string      `bson:"role" mgoValidate:"enum=admin|member"`
+		Code    string      `bson:"code" mgoValidate:"regex=^[A-Z]{2,3}$"`
+		Tags    []string    `bson:"tags" mgoValidate:"len=2"`
+		Scores  []int       `bson:"scores" mgoValidate:"max=10"`
+		Profile UserProfile `bson:"profile" mgoID:"false"`
+	}
+
+	entityModelSchema, err := schema.BuildSchemaForModel(User{}, schemaopt.SchemaOptions{})
+	s.Nil(err)
+
+	type TestCase struct {
+		Name         string
+		ModifyUser   func(user *User)
+		ExpectedPath string
+		ExpectedRule string
+	}
+
+	testCases := []TestCase{
+		{Name: "valid", ModifyUser: func(_ *User) {}},
+		{Name: "min length", ModifyUser: func(user *User) { user.Name = "G" }, ExpectedPath: "$root.name", ExpectedRule: "minLen"},
+		{Name: "max", ModifyUser: func(user *User) { user.Age = 121 }, ExpectedPath: "$root.age", ExpectedRule: "max"},
+		{Name: "email", ModifyUser: func(user *User) { user.Email = "Gopher <gopher@example.com>" }, ExpectedPath: "$root.email", ExpectedRule: "email"},
+		{Name: "enum", ModifyUser: func(user *User) { user.Role = "owner" }, ExpectedPath: "$root.role", ExpectedRule: "enum"},
+		{Name: "regex", ModifyUser: func(user *User) { user.Code = "go" }, ExpectedPath: "$root.code", ExpectedRule: "regex"},
+		{Name: "array length", ModifyUser: func(user *User) { user.Tags = []string{"go"} }, ExpectedPath: "$root.tags", ExpectedRule: "len"},
+		{Name: "array element", ModifyUser: func(user *User) { user.Scores = []int{1, 11} }, ExpectedPath: "$root.scores", ExpectedRule: "max"},
+		{Name: "nested field", ModifyUser: func(user *User) { user.Profile.Bio = "gopher from go" }, ExpectedPath: "$root.profile.bio", ExpectedRule: "maxLen"},
+	}
+
+	for _, testCase := range testCases {
+		user := User{
+			ID:      primitive.NewObjectID().Hex(),
+			Name:    "Gopher",
+			Age:     18,
+			Email:   "gopher@example.com",
+			Role:    "admin",
+			Code:    "GO",
+			Tags:    []string{"go", "mongo"},
+			Scores:  []int{1, 10},
+			Profile: UserProfile{Bio: "gopher"},
+		}
+		testCase.ModifyUser(&user)
+
+		marshalledDoc, err := bson.Marshal(user)
+		s.Nil(err)
+
+		var doc bson.D
+		s.Nil(bson.Unmarshal(marshalledDoc, &doc))
+
+		err = bsondoc.Build(context.TODO(), &doc, entityModelSchema, bsondoc.TranslateToEnumMongo)
+
+		if testCase.ExpectedRule == "" {
+			s.Nil(err, testCase.Name)
+			continue
+		}
+
+		var validationErr errors.ValidationError
+		s.ErrorAs(err, &validationErr, testCase.Name)
+		s.ErrorIs(err, errors.ErrValidation, testCase.Name)
+		s.Equal(testCase.ExpectedPath, validationErr.Path, testCase.Name)
+		s.Equal(testCase.ExpectedRule, validationErr.Rule, testCase.Name)
+	}
+}
+
+func (s *BuildBSONDocSuite) TestBuildBSONDocSkipsValidationForEntityModel() {
+	type User struct {
+		Age int `bson:"age" mgoValidate:"max=120"`
+	}
+
+	entityModelSchema, err := schema.BuildSchemaForModel(User{}, schemaopt.SchemaOptions{})
+	s.Nil(err)
+
+	doc := bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "age", Value: int32(150)}}
+	err = bsondoc.Build(context.TODO(), &doc, entityModelSchema, bsondoc.TranslateToEnumEntityModel)
+	s.Nil(err)
+}
+
+func (s *BuildBSONDocSuite) TestBuildBSONDocWithDeselectedFields() {
+	type User struct {
+		Name         string `bson:"name"`
+		PasswordHash string `bson:"passwordHash" mgoSelect:"false"`
+	}
+
+	entityModelSchema, err := schema.BuildSchemaForModel(User{}, schemaopt.SchemaOptions{})
+	s.Nil(err)
+
+	// deselected fields are excluded from the found docs, hence they are not expected while translating to entity model.
+	doc := bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "name", Value: "Gopher"}}
+	err = bsondoc.Build(context.TODO(), &doc, entityModelSchema, bsondoc.TranslateToEnumEntityModel)
+	s.Nil(err)
+	s.Len(doc, 2)
+
+	// but they are still required while translating to mongo doc.
+	doc = bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "name", Value: "Gopher"}}
+	err = bsondoc.Build(context.TODO(), &doc, entityModelSchema, bsondoc.TranslateToEnumMongo)
+	s.NotNil(err)
+}
+
+type piiOption struct{}
+
+func (o piiOption) GetOptName() string {
+	return "PII"
+}
+
+func (o piiOption) GetBSONTagName() string {
+	return "mgoPII"
+}
+
+func (o piiOption) IsApplicable(field reflect.StructField) bool {
+	return field.Tag.Get(o.GetBSONTagName()) != ""
+}
+
+func (o piiOption) GetDefaultValue(_ reflect.StructField) interface{} {
+	return nil
+}
+
+func (o piiOption) GetValue(field reflect.StructField) (interface{}, error) {
+	return field.Tag.Get(o.GetBSONTagName()), nil
+}
+
+func (o piiOption) OnBuildMongoDoc(_ context.Context, _ string, optValue, fieldValue interface{}) (interface{}, error) {
+	return fmt.Sprintf("%s:%v", optValue, fieldValue), nil
+}
+
+func (o piiOption) OnBuildEntityModelDoc(_ context.Context, _ string, optValue, fieldValue interface{}) (interface{}, error) {
+	return strings.TrimPrefix(fieldValue.(string), fmt.Sprintf("%s:", optValue)), nil
+}
+
+func init() {
+	if err := fieldopt.Register(piiOption{}); err != nil {
+		panic(err)
+	}
+}
+
+func (s *BuildBSONDocSuite) TestBuildBSONDocWithRegisteredFieldOption() {
+	type User struct {
+		Name  string `bson:"name"`
+		Email string `bson:"email" mgoPII:"enc"`
+	}
+
+	// names and bson tags should be unique.
+	s.ErrorContains(fieldopt.Register(piiOption{}), "already exists")
+
+	entityModelSchema, err := schema.BuildSchemaForModel(User{}, schemaopt.SchemaOptions{})
+	s.Nil(err)
+	s.Equal(map[string]interface{}{"PII": "enc"}, entityModelSchema.Nodes["$root.email"].Props.Options.Custom)
+	s.Nil(entityModelSchema.Nodes["$root.name"].Props.Options.Custom)
+
+	id := primitive.NewObjectID()
+	doc := bson.D{{Key: "_id", Value: id}, {Key: "name", Value: "Gopher"}, {Key: "email", Value: "gopher@mgod.com"}}
+
+	err = bsondoc.Build(context.TODO(), &doc, entityModelSchema, bsondoc.TranslateToEnumMongo)
+	s.Nil(err)
+	s.Equal(bson.D{{Key: "_id", Value: id}, {Key: "name", Value: "Gopher"}, {Key: "email", Value: "enc:gopher@mgod.com"}}, doc)
+
+	err = bsondoc.Build(context.TODO(), &doc, entityModelSchema, bsondoc.TranslateToEnumEntityModel)
+	s.Nil(err)
+	s.Equal(bson.D{{Key: "_id", Value: id.Hex()}, {Key: "name", Value: "Gopher"}, {Key: "email", Value: "gopher@mgod.com"}}, doc)
+}
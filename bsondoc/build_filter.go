@@ -0,0 +1,278 @@
+package bsondoc
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/Lyearn/mgod/schema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BuildFilter translates the values of the provided filter query according to the provided [schema.EntityModelSchema]
+// so that the filter can be written in the same representation as the entity model (e.g. string ids and ISO dates).
+//
+// Logical operators ($and, $or, $nor), comparison operators, $in/$nin/$all, $not, $elemMatch and dotted paths are supported.
+// Fields which are not present in the schema and operators which don't hold field values (e.g. $expr) are kept as it is.
+// The provided filter is not modified, a translated copy is returned instead.
+func BuildFilter(ctx context.Context, filter interface{}, entityModelSchema *schema.EntityModelSchema) (interface{}, error) {
+	if entityModelSchema == nil || filter == nil {
+		return filter, nil
+	}
+
+	return buildFilter(ctx, filter, entityModelSchema.Nodes, entityModelSchema.Root.Path)
+}
+
+// buildFilter translates a filter document whose fields are relative to the provided parent path.
+func buildFilter(
+	ctx context.Context,
+	filter interface{},
+	schemaNodes map[string]*schema.TreeNode,
+	parent string,
+) (interface{}, error) {
+	return mapDocValues(filter, func(key string, value interface{}) (interface{}, error) {
+		return buildFilterElem(ctx, key, value, schemaNodes, parent)
+	})
+}
+
+func buildFilterElem(
+	ctx context.Context,
+	key string,
+	value interface{},
+	schemaNodes map[string]*schema.TreeNode,
+	parent string,
+) (interface{}, error) {
+	if strings.HasPrefix(key, "$") {
+		switch key {
+		case "$and", "$or", "$nor":
+			return mapArrayValues(value, func(subFilter interface{}) (interface{}, error) {
+				return buildFilter(ctx, subFilter, schemaNodes, parent)
+			})
+		default:
+			// top level operators like $expr, $text, $where etc. doesn't hold schema field values.
+			return value, nil
+		}
+	}
+
+	schemaNode, path := getSchemaNodeForQueryField(key, parent, schemaNodes)
+	if schemaNode == nil {
+		// skip translation for fields which are not present in the schema.
+		return value, nil
+	}
+
+	return buildFilterValue(ctx, value, schemaNodes, schemaNode, path)
+}
+
+// buildFilterValue translates the value of a filter field which can either be an operator doc or a value to match.
+func buildFilterValue(
+	ctx context.Context,
+	value interface{},
+	schemaNodes map[string]*schema.TreeNode,
+	schemaNode *schema.TreeNode,
+	path string,
+) (interface{}, error) {
+	if !isOperatorDoc(value) {
+		return buildFilterOperand(ctx, value, schemaNodes, schemaNode, path)
+	}
+
+	return mapDocValues(value, func(operator string, operand interface{}) (interface{}, error) {
+		switch operator {
+		case "$eq", "$ne", "$gt", "$gte", "$lt", "$lte":
+			return buildFilterOperand(ctx, operand, schemaNodes, schemaNode, path)
+
+		case "$in", "$nin", "$all":
+			return mapArrayValues(operand, func(elem interface{}) (interface{}, error) {
+				return buildFilterOperand(ctx, elem, schemaNodes, schemaNode, path)
+			})
+
+		case "$not":
+			return buildFilterValue(ctx, operand, schemaNodes, schemaNode, path)
+
+		case "$elemMatch":
+			elemPath := schema.GetPathForField(arrayElemPathKey, path)
+			elemNode, ok := schemaNodes[elemPath]
+			if !ok {
+				return operand, nil
+			}
+
+			// elements holding objects are matched using a filter relative to the element.
+			if elemNode.Props.Type == reflect.Struct {
+				return buildFilter(ctx, operand, schemaNodes, elemPath)
+			}
+
+			return buildFilterValue(ctx, operand, schemaNodes, elemNode, elemPath)
+
+		default:
+			// operators like $exists, $type, $size, $regex etc. doesn't hold schema field values.
+			return operand, nil
+		}
+	})
+}
+
+// buildFilterOperand translates a value which is matched against the provided schema node.
+func buildFilterOperand(
+	ctx context.Context,
+	value interface{},
+	schemaNodes map[string]*schema.TreeNode,
+	schemaNode *schema.TreeNode,
+	path string,
+) (interface{}, error) {
+	if value == nil {
+		//nolint:nilnil // nil is matched as it is
+		return nil, nil
+	}
+
+	if _, ok := value.(primitive.Regex); ok {
+		return value, nil
+	}
+
+	elemPath := schema.GetPathForField(arrayElemPathKey, path)
+	if elemNode, ok := schemaNodes[elemPath]; ok {
+		// an array value is matched against the whole array, whereas any other value is matched against the array elements.
+		if values, isArray := toBSONArray(value); isArray {
+			return mapArrayValues(values, func(elem interface{}) (interface{}, error) {
+				return buildFilterOperand(ctx, elem, schemaNodes, elemNode, elemPath)
+			})
+		}
+
+		return buildFilterOperand(ctx, value, schemaNodes, elemNode, elemPath)
+	}
+
+	if isDoc(value) {
+		return buildFilter(ctx, value, schemaNodes, path)
+	}
+
+	modifiedValue := value
+	for _, transformer := range schemaNode.Props.Transformers {
+		if transformer == nil {
+			continue
+		}
+
+		var err error
+		if modifiedValue, err = transformer.TransformForMongoDoc(modifiedValue); err != nil {
+			return nil, err
+		}
+	}
+
+	return modifiedValue, nil
+}
+
+// isDoc reports whether the provided value is a document.
+func isDoc(value interface{}) bool {
+	switch value.(type) {
+	case bson.D, bson.M, map[string]interface{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// isOperatorDoc reports whether the provided value is a non empty document having only operators as keys.
+func isOperatorDoc(value interface{}) bool {
+	if !isDoc(value) {
+		return false
+	}
+
+	hasKeys := false
+	isOperator := true
+
+	_, _ = mapDocValues(value, func(key string, value interface{}) (interface{}, error) {
+		hasKeys = true
+		isOperator = isOperator && strings.HasPrefix(key, "$")
+
+		return value, nil
+	})
+
+	return hasKeys && isOperator
+}
+
+// mapDocValues returns a copy of the provided document with every value replaced by the result of the mapper function.
+// Values other than documents are returned as it is.
+func mapDocValues(doc interface{}, mapper func(key string, value interface{}) (interface{}, error)) (interface{}, error) {
+	switch typedDoc := doc.(type) {
+	case bson.D:
+		mappedDoc := make(bson.D, 0, len(typedDoc))
+		for _, elem := range typedDoc {
+			mappedValue, err := mapper(elem.Key, elem.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			mappedDoc = append(mappedDoc, bson.E{Key: elem.Key, Value: mappedValue})
+		}
+
+		return mappedDoc, nil
+
+	case bson.M:
+		mappedDoc, err := mapMapValues(typedDoc, mapper)
+		return bson.M(mappedDoc), err
+
+	case map[string]interface{}:
+		return mapMapValues(typedDoc, mapper)
+
+	default:
+		return doc, nil
+	}
+}
+
+func mapMapValues(
+	doc map[string]interface{},
+	mapper func(key string, value interface{}) (interface{}, error),
+) (map[string]interface{}, error) {
+	mappedDoc := make(map[string]interface{}, len(doc))
+	for key, value := range doc {
+		mappedValue, err := mapper(key, value)
+		if err != nil {
+			return nil, err
+		}
+
+		mappedDoc[key] = mappedValue
+	}
+
+	return mappedDoc, nil
+}
+
+// mapArrayValues returns a bson.A with every element of the provided array replaced by the result of the mapper function.
+// Values other than arrays are returned as it is.
+func mapArrayValues(arr interface{}, mapper func(value interface{}) (interface{}, error)) (interface{}, error) {
+	values, ok := toBSONArray(arr)
+	if !ok {
+		return arr, nil
+	}
+
+	mappedValues := make(bson.A, 0, len(values))
+	for _, value := range values {
+		mappedValue, err := mapper(value)
+		if err != nil {
+			return nil, err
+		}
+
+		mappedValues = append(mappedValues, mappedValue)
+	}
+
+	return mappedValues, nil
+}
+
+// toBSONArray converts the provided value to bson.A if the value is a slice (other than a document or binary data).
+func toBSONArray(value interface{}) (bson.A, bool) {
+	switch typedValue := value.(type) {
+	case bson.A:
+		return typedValue, true
+	case bson.D, []byte:
+		return nil, false
+	}
+
+	// arrays are not considered because fixed size types like primitive.ObjectID are represented as arrays.
+	reflectValue := reflect.ValueOf(value)
+	if reflectValue.Kind() != reflect.Slice {
+		return nil, false
+	}
+
+	values := make(bson.A, 0, reflectValue.Len())
+	for i := 0; i < reflectValue.Len(); i++ {
+		values = append(values, reflectValue.Index(i).Interface())
+	}
+
+	return values, true
+}
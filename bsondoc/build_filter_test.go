@@ -0,0 +1,163 @@
+package bsondoc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Lyearn/mgod/bsondoc"
+	"github.com/Lyearn/mgod/dateformatter"
+	"github.com/Lyearn/mgod/schema"
+	"github.com/Lyearn/mgod/schema/schemaopt"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type BuildFilterSuite struct {
+	suite.Suite
+	*require.Assertions
+
+	schema *schema.EntityModelSchema
+}
+
+type filterTestUserProject struct {
+	ProjectID   string `bson:"projectId" mgoType:"id"`
+	CompletedAt string `bson:"completedAt" mgoType:"date"`
+}
+
+type filterTestMetadata struct {
+	JoinedOn string                  `bson:"joinedOn" mgoType:"date"`
+	TeamIDs  []string                `bson:"teamIds" mgoType:"id"`
+	Projects []filterTestUserProject `bson:"projects" mgoID:"false"`
+}
+
+type filterTestUser struct {
+	ID       string              `bson:"_id" mgoType:"id"`
+	Name     string              `bson:"name"`
+	Metadata *filterTestMetadata `bson:"meta"`
+}
+
+func TestBuildFilterSuite(t *testing.T) {
+	s := new(BuildFilterSuite)
+	suite.Run(t, s)
+}
+
+func (s *BuildFilterSuite) SetupSuite() {
+	entityModelSchema, err := schema.BuildSchemaForModel(filterTestUser{}, schemaopt.SchemaOptions{Timestamps: true})
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	s.schema = entityModelSchema
+}
+
+func (s *BuildFilterSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *BuildFilterSuite) TestBuildFilter() {
+	type TestCase struct {
+		Name           string
+		Filter         interface{}
+		ExpectedFilter interface{}
+	}
+
+	ids := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID()}
+	joinedOn := primitive.NewDateTimeFromTime(time.Now())
+	joinedOnStr, _ := dateformatter.New(joinedOn.Time()).GetISOString()
+
+	testCases := []TestCase{
+		{
+			Name:           "id equality",
+			Filter:         bson.D{{Key: "_id", Value: ids[0].Hex()}},
+			ExpectedFilter: bson.D{{Key: "_id", Value: ids[0]}},
+		},
+		{
+			Name:           "already translated value",
+			Filter:         bson.M{"_id": ids[0]},
+			ExpectedFilter: bson.M{"_id": ids[0]},
+		},
+		{
+			Name:           "comparison operators on dotted path",
+			Filter:         bson.M{"meta.joinedOn": bson.M{"$gte": joinedOnStr, "$exists": true}},
+			ExpectedFilter: bson.M{"meta.joinedOn": bson.M{"$gte": joinedOn, "$exists": true}},
+		},
+		{
+			Name:           "in operator",
+			Filter:         bson.M{"_id": bson.M{"$in": []string{ids[0].Hex(), ids[1].Hex()}}},
+			ExpectedFilter: bson.M{"_id": bson.M{"$in": bson.A{ids[0], ids[1]}}},
+		},
+		{
+			Name: "logical operators",
+			Filter: bson.D{{Key: "$or", Value: bson.A{
+				bson.M{"_id": ids[0].Hex()},
+				bson.D{{Key: "$and", Value: bson.A{bson.M{"meta.teamIds": ids[1].Hex()}, bson.M{"name": "gopher"}}}},
+			}}},
+			ExpectedFilter: bson.D{{Key: "$or", Value: bson.A{
+				bson.M{"_id": ids[0]},
+				bson.D{{Key: "$and", Value: bson.A{bson.M{"meta.teamIds": ids[1]}, bson.M{"name": "gopher"}}}},
+			}}},
+		},
+		{
+			Name:           "array equality",
+			Filter:         bson.M{"meta.teamIds": bson.A{ids[0].Hex(), ids[1].Hex()}},
+			ExpectedFilter: bson.M{"meta.teamIds": bson.A{ids[0], ids[1]}},
+		},
+		{
+			Name:           "implicit array element path",
+			Filter:         bson.M{"meta.projects.projectId": ids[0].Hex(), "meta.projects.0.completedAt": joinedOnStr},
+			ExpectedFilter: bson.M{"meta.projects.projectId": ids[0], "meta.projects.0.completedAt": joinedOn},
+		},
+		{
+			Name: "elemMatch on array of objects",
+			Filter: bson.M{"meta.projects": bson.M{"$elemMatch": bson.M{
+				"projectId":   bson.M{"$ne": ids[0].Hex()},
+				"completedAt": bson.M{"$lt": joinedOnStr},
+			}}},
+			ExpectedFilter: bson.M{"meta.projects": bson.M{"$elemMatch": bson.M{
+				"projectId":   bson.M{"$ne": ids[0]},
+				"completedAt": bson.M{"$lt": joinedOn},
+			}}},
+		},
+		{
+			Name:           "elemMatch on array of primitives",
+			Filter:         bson.M{"meta.teamIds": bson.M{"$elemMatch": bson.M{"$in": bson.A{ids[0].Hex()}}}},
+			ExpectedFilter: bson.M{"meta.teamIds": bson.M{"$elemMatch": bson.M{"$in": bson.A{ids[0]}}}},
+		},
+		{
+			Name:           "meta fields",
+			Filter:         bson.M{"createdAt": bson.M{"$not": bson.M{"$gt": joinedOnStr}}},
+			ExpectedFilter: bson.M{"createdAt": bson.M{"$not": bson.M{"$gt": joinedOn}}},
+		},
+		{
+			Name:           "unknown fields and non value operators",
+			Filter:         bson.M{"unknown": "value", "name": bson.M{"$regex": "^go"}, "$expr": bson.M{"$eq": bson.A{"$a", "$b"}}},
+			ExpectedFilter: bson.M{"unknown": "value", "name": bson.M{"$regex": "^go"}, "$expr": bson.M{"$eq": bson.A{"$a", "$b"}}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		filter, err := bsondoc.BuildFilter(context.TODO(), testCase.Filter, s.schema)
+
+		s.Nil(err, testCase.Name)
+		s.Equal(testCase.ExpectedFilter, filter, testCase.Name)
+	}
+}
+
+func (s *BuildFilterSuite) TestBuildFilterDoesNotModifyInput() {
+	id := primitive.NewObjectID()
+	filter := bson.D{{Key: "_id", Value: id.Hex()}}
+
+	_, err := bsondoc.BuildFilter(context.TODO(), filter, s.schema)
+
+	s.Nil(err)
+	s.Equal(id.Hex(), filter[0].Value)
+}
+
+func (s *BuildFilterSuite) TestBuildFilterWithInvalidValue() {
+	_, err := bsondoc.BuildFilter(context.TODO(), bson.M{"_id": "invalid-id"}, s.schema)
+
+	s.NotNil(err)
+}
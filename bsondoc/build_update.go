@@ -0,0 +1,192 @@
+package bsondoc
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Lyearn/mgod/errors"
+	"github.com/Lyearn/mgod/schema"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// BuildUpdate translates the payloads of the update operators in the provided update query according to the provided
+// [schema.EntityModelSchema].
+//
+// Field paths (including the positional operators) are resolved against the schema and unknown paths are rejected.
+// Values of $set, $setOnInsert, $min, $max, $push, $addToSet and $pullAll are built in the same way as an inserted doc
+// i.e. transformers are applied, validation rules are checked, hooks of the user-defined field options are invoked and
+// nested _id and default values are added to objects. Conditions of $pull are translated in the same way as a filter
+// query. The provided update query is not modified, a translated copy is returned instead.
+func BuildUpdate(ctx context.Context, update interface{}, entityModelSchema *schema.EntityModelSchema) (interface{}, error) {
+	if entityModelSchema == nil || update == nil {
+		return update, nil
+	}
+
+	schemaNodes := entityModelSchema.Nodes
+	parent := entityModelSchema.Root.Path
+
+	return mapDocValues(update, func(operator string, payload interface{}) (interface{}, error) {
+		if !strings.HasPrefix(operator, "$") {
+			// not an update operator. leaving it for MongoDB to reject.
+			return payload, nil
+		}
+
+		return mapDocValues(payload, func(field string, value interface{}) (interface{}, error) {
+			schemaNode, path := getSchemaNodeForQueryField(field, parent, schemaNodes)
+			if schemaNode == nil {
+				return nil, errors.NewNotFoundError(errors.NotFoundError{
+					Underlying: fmt.Sprintf("%s update operator", operator),
+					Value:      fmt.Sprintf("path - %s", path),
+				})
+			}
+
+			// renamed field should also be present in the schema.
+			if newField, ok := value.(string); ok && operator == "$rename" {
+				if newSchemaNode, newPath := getSchemaNodeForQueryField(newField, parent, schemaNodes); newSchemaNode == nil {
+					return nil, errors.NewNotFoundError(errors.NotFoundError{
+						Underlying: fmt.Sprintf("%s update operator", operator),
+						Value:      fmt.Sprintf("path - %s", newPath),
+					})
+				}
+			}
+
+			return buildUpdateOperatorValue(ctx, operator, value, schemaNodes, path)
+		})
+	})
+}
+
+func buildUpdateOperatorValue(
+	ctx context.Context,
+	operator string,
+	value interface{},
+	schemaNodes map[string]*schema.TreeNode,
+	path string,
+) (interface{}, error) {
+	switch operator {
+	case "$set", "$setOnInsert", "$min", "$max":
+		return buildUpdateValue(ctx, value, schemaNodes, path)
+
+	case "$push", "$addToSet":
+		elemPath, err := getArrayElemPath(operator, schemaNodes, path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isModifierDoc(value) {
+			return buildUpdateValue(ctx, value, schemaNodes, elemPath)
+		}
+
+		return mapDocValues(value, func(modifier string, modifierValue interface{}) (interface{}, error) {
+			if modifier != "$each" {
+				// modifiers like $position, $slice and $sort doesn't hold schema field values.
+				return modifierValue, nil
+			}
+
+			return mapArrayValues(modifierValue, func(elem interface{}) (interface{}, error) {
+				return buildUpdateValue(ctx, elem, schemaNodes, elemPath)
+			})
+		})
+
+	case "$pullAll":
+		elemPath, err := getArrayElemPath(operator, schemaNodes, path)
+		if err != nil {
+			return nil, err
+		}
+
+		return mapArrayValues(value, func(elem interface{}) (interface{}, error) {
+			return buildUpdateValue(ctx, elem, schemaNodes, elemPath)
+		})
+
+	case "$pull":
+		elemPath, err := getArrayElemPath(operator, schemaNodes, path)
+		if err != nil {
+			return nil, err
+		}
+
+		elemNode := schemaNodes[elemPath]
+
+		// elements holding objects are matched using a filter relative to the element.
+		if elemNode.Props.Type == reflect.Struct {
+			return buildFilter(ctx, value, schemaNodes, elemPath)
+		}
+
+		return buildFilterValue(ctx, value, schemaNodes, elemNode, elemPath)
+
+	default:
+		// operators like $inc, $mul, $unset, $currentDate etc. doesn't hold values which need to be translated.
+		return value, nil
+	}
+}
+
+// buildUpdateValue builds the provided value (which can be of any type, including structs) for the provided schema path.
+func buildUpdateValue(
+	ctx context.Context,
+	value interface{},
+	schemaNodes map[string]*schema.TreeNode,
+	path string,
+) (interface{}, error) {
+	bsonValue, err := toBSONValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateNodeValue(bsonValue, schemaNodes, path); err != nil {
+		return nil, err
+	}
+
+	convertedValue, err := getConvertedValueForNode(ctx, bsonValue, schemaNodes, path, TranslateToEnumMongo)
+	if err != nil {
+		return nil, err
+	}
+
+	return runFieldOptionHooks(ctx, convertedValue, schemaNodes, path, TranslateToEnumMongo)
+}
+
+// toBSONValue converts the provided value to its bson representation i.e. structs and maps are converted to bson.D,
+// slices are converted to bson.A and the primitive values are kept as it is.
+func toBSONValue(value interface{}) (interface{}, error) {
+	if value == nil {
+		//nolint:nilnil // nil is a valid bson value
+		return nil, nil
+	}
+
+	marshalledDoc, err := bson.Marshal(bson.D{{Key: "value", Value: value}})
+	if err != nil {
+		return nil, err
+	}
+
+	var doc bson.D
+	if err = bson.Unmarshal(marshalledDoc, &doc); err != nil {
+		return nil, err
+	}
+
+	return doc[0].Value, nil
+}
+
+// getArrayElemPath returns the schema path of the elements of the array at the provided path.
+func getArrayElemPath(operator string, schemaNodes map[string]*schema.TreeNode, path string) (string, error) {
+	elemPath := schema.GetPathForField(arrayElemPathKey, path)
+	if _, ok := schemaNodes[elemPath]; !ok {
+		return "", errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: fmt.Sprintf("%s update operator", operator),
+			Got:        fmt.Sprintf("non array field at path - %s", path),
+			Expected:   "array field",
+		})
+	}
+
+	return elemPath, nil
+}
+
+// isModifierDoc reports whether the provided value is a document containing the $each modifier.
+func isModifierDoc(value interface{}) bool {
+	hasEachModifier := false
+
+	_, _ = mapDocValues(value, func(key string, value interface{}) (interface{}, error) {
+		hasEachModifier = hasEachModifier || key == "$each"
+		return value, nil
+	})
+
+	return hasEachModifier
+}
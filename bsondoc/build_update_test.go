@@ -0,0 +1,217 @@
+package bsondoc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Lyearn/mgod/bsondoc"
+	"github.com/Lyearn/mgod/dateformatter"
+	"github.com/Lyearn/mgod/errors"
+	"github.com/Lyearn/mgod/schema"
+	"github.com/Lyearn/mgod/schema/schemaopt"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type BuildUpdateSuite struct {
+	suite.Suite
+	*require.Assertions
+
+	schema *schema.EntityModelSchema
+}
+
+type updateTestUserProject struct {
+	ProjectID   string `bson:"projectId" mgoType:"id"`
+	CompletedAt string `bson:"completedAt" mgoType:"date"`
+}
+
+type updateTestMetadata struct {
+	JoinedOn string   `bson:"joinedOn" mgoType:"date"`
+	TeamIDs  []string `bson:"teamIds" mgoType:"id" mgoDefault:"[]"`
+}
+
+type updateTestUser struct {
+	ID       string                  `bson:"_id" mgoType:"id"`
+	Name     string                  `bson:"name"`
+	Age      int                     `bson:"age" mgoValidate:"min=0"`
+	Metadata *updateTestMetadata     `bson:"meta"`
+	Projects []updateTestUserProject `bson:"projects" mgoID:"false"`
+	Labels   []string                `bson:"labels" mgoValidate:"enum=red|green"`
+}
+
+func TestBuildUpdateSuite(t *testing.T) {
+	s := new(BuildUpdateSuite)
+	suite.Run(t, s)
+}
+
+func (s *BuildUpdateSuite) SetupSuite() {
+	entityModelSchema, err := schema.BuildSchemaForModel(updateTestUser{}, schemaopt.SchemaOptions{})
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	s.schema = entityModelSchema
+}
+
+func (s *BuildUpdateSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *BuildUpdateSuite) TestBuildUpdate() {
+	type TestCase struct {
+		Name           string
+		Update         interface{}
+		ExpectedUpdate interface{}
+	}
+
+	ids := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID()}
+	completedAt := primitive.NewDateTimeFromTime(time.Now())
+	completedAtStr, _ := dateformatter.New(completedAt.Time()).GetISOString()
+
+	testCases := []TestCase{
+		{
+			Name: "set primitive values",
+			Update: bson.D{{Key: "$set", Value: bson.D{
+				{Key: "name", Value: "gopher"},
+				{Key: "meta.joinedOn", Value: completedAtStr},
+				{Key: "meta.teamIds.1", Value: ids[0].Hex()},
+			}}},
+			ExpectedUpdate: bson.D{{Key: "$set", Value: bson.D{
+				{Key: "name", Value: "gopher"},
+				{Key: "meta.joinedOn", Value: completedAt},
+				{Key: "meta.teamIds.1", Value: ids[0]},
+			}}},
+		},
+		{
+			Name: "set positional array element field",
+			Update: bson.D{{Key: "$set", Value: bson.D{
+				{Key: "projects.$.projectId", Value: ids[0].Hex()},
+				{Key: "projects.$[project].completedAt", Value: completedAtStr},
+			}}},
+			ExpectedUpdate: bson.D{{Key: "$set", Value: bson.D{
+				{Key: "projects.$.projectId", Value: ids[0]},
+				{Key: "projects.$[project].completedAt", Value: completedAt},
+			}}},
+		},
+		{
+			Name: "push struct element",
+			Update: bson.D{{Key: "$push", Value: bson.D{
+				{Key: "projects", Value: updateTestUserProject{ProjectID: ids[0].Hex(), CompletedAt: completedAtStr}},
+			}}},
+			ExpectedUpdate: bson.D{{Key: "$push", Value: bson.D{
+				{Key: "projects", Value: bson.D{
+					{Key: "projectId", Value: ids[0]},
+					{Key: "completedAt", Value: completedAt},
+				}},
+			}}},
+		},
+		{
+			Name: "add to set with each modifier",
+			Update: bson.D{{Key: "$addToSet", Value: bson.D{
+				{Key: "meta.teamIds", Value: bson.D{{Key: "$each", Value: []string{ids[0].Hex(), ids[1].Hex()}}}},
+			}}},
+			ExpectedUpdate: bson.D{{Key: "$addToSet", Value: bson.D{
+				{Key: "meta.teamIds", Value: bson.D{{Key: "$each", Value: bson.A{ids[0], ids[1]}}}},
+			}}},
+		},
+		{
+			Name: "pull with condition",
+			Update: bson.D{
+				{Key: "$pull", Value: bson.D{
+					{Key: "projects", Value: bson.D{{Key: "projectId", Value: ids[0].Hex()}}},
+					{Key: "meta.teamIds", Value: bson.D{{Key: "$in", Value: bson.A{ids[1].Hex()}}}},
+				}},
+				{Key: "$pullAll", Value: bson.D{
+					{Key: "meta.teamIds", Value: bson.A{ids[0].Hex()}},
+				}},
+			},
+			ExpectedUpdate: bson.D{
+				{Key: "$pull", Value: bson.D{
+					{Key: "projects", Value: bson.D{{Key: "projectId", Value: ids[0]}}},
+					{Key: "meta.teamIds", Value: bson.D{{Key: "$in", Value: bson.A{ids[1]}}}},
+				}},
+				{Key: "$pullAll", Value: bson.D{
+					{Key: "meta.teamIds", Value: bson.A{ids[0]}},
+				}},
+			},
+		},
+		{
+			Name: "operators without translatable values",
+			Update: bson.D{
+				{Key: "$inc", Value: bson.D{{Key: "age", Value: 1}}},
+				{Key: "$unset", Value: bson.D{{Key: "meta", Value: ""}}},
+			},
+			ExpectedUpdate: bson.D{
+				{Key: "$inc", Value: bson.D{{Key: "age", Value: 1}}},
+				{Key: "$unset", Value: bson.D{{Key: "meta", Value: ""}}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		update, err := bsondoc.BuildUpdate(context.TODO(), testCase.Update, s.schema)
+
+		s.Nil(err, testCase.Name)
+		s.Equal(testCase.ExpectedUpdate, update, testCase.Name)
+	}
+}
+
+func (s *BuildUpdateSuite) TestBuildUpdateForStructValue() {
+	joinedOn := primitive.NewDateTimeFromTime(time.Now())
+	joinedOnStr, _ := dateformatter.New(joinedOn.Time()).GetISOString()
+
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "meta", Value: updateTestMetadata{JoinedOn: joinedOnStr}},
+	}}}
+
+	builtUpdate, err := bsondoc.BuildUpdate(context.TODO(), update, s.schema)
+	s.Nil(err)
+
+	metadata, ok := builtUpdate.(bson.D)[0].Value.(bson.D)[0].Value.(bson.D)
+	s.True(ok)
+
+	s.Equal("joinedOn", metadata[0].Key)
+	s.Equal(joinedOn, metadata[0].Value)
+	s.Equal("teamIds", metadata[1].Key)
+	s.Nil(metadata[1].Value)
+	// _id is added to the nested object.
+	s.Equal("_id", metadata[2].Key)
+	s.IsType(primitive.ObjectID{}, metadata[2].Value)
+}
+
+func (s *BuildUpdateSuite) TestBuildUpdateWithInvalidPaths() {
+	updates := []bson.D{
+		{{Key: "$set", Value: bson.D{{Key: "unknown", Value: "value"}}}},
+		{{Key: "$set", Value: bson.D{{Key: "meta.unknown", Value: "value"}}}},
+		{{Key: "$rename", Value: bson.D{{Key: "name", Value: "unknown"}}}},
+		{{Key: "$push", Value: bson.D{{Key: "name", Value: "value"}}}},
+	}
+
+	for _, update := range updates {
+		_, err := bsondoc.BuildUpdate(context.TODO(), update, s.schema)
+
+		s.NotNil(err)
+	}
+}
+
+func (s *BuildUpdateSuite) TestBuildUpdateWithInvalidValues() {
+	updates := []bson.D{
+		{{Key: "$set", Value: bson.D{{Key: "age", Value: -1}}}},
+		{{Key: "$set", Value: bson.D{{Key: "labels", Value: []string{"red", "blue"}}}}},
+		{{Key: "$set", Value: bson.D{{Key: "labels.0", Value: "blue"}}}},
+		{{Key: "$push", Value: bson.D{{Key: "labels", Value: "blue"}}}},
+		{{Key: "$addToSet", Value: bson.D{{Key: "labels", Value: bson.D{{Key: "$each", Value: bson.A{"red", "blue"}}}}}}},
+	}
+
+	for _, update := range updates {
+		_, err := bsondoc.BuildUpdate(context.TODO(), update, s.schema)
+
+		s.ErrorIs(err, errors.ErrValidation)
+	}
+
+	_, err := bsondoc.BuildUpdate(context.TODO(), bson.D{{Key: "$push", Value: bson.D{{Key: "labels", Value: "red"}}}}, s.schema)
+	s.Nil(err)
+}
@@ -0,0 +1,49 @@
+package bsondoc
+
+import (
+	"context"
+
+	"github.com/Lyearn/mgod/schema"
+	"github.com/Lyearn/mgod/schema/fieldopt"
+)
+
+// runFieldOptionHooks invokes the hooks of the user-defined field options (see [fieldopt.FieldOptionHook]) applied
+// on the schema node at the provided path, and returns the value returned by the last hook.
+func runFieldOptionHooks(
+	ctx context.Context,
+	value interface{},
+	schemaNodes map[string]*schema.TreeNode,
+	path string,
+	translateTo TranslateToEnum,
+) (interface{}, error) {
+	schemaNode, ok := schemaNodes[path]
+	if !ok || len(schemaNode.Props.Options.Custom) == 0 {
+		return value, nil
+	}
+
+	for _, opt := range fieldopt.GetRegisteredOptions() {
+		optValue, ok := schemaNode.Props.Options.Custom[opt.GetOptName()]
+		if !ok {
+			continue
+		}
+
+		hook, ok := opt.(fieldopt.FieldOptionHook)
+		if !ok {
+			continue
+		}
+
+		var err error
+
+		if translateTo == TranslateToEnumMongo {
+			value, err = hook.OnBuildMongoDoc(ctx, path, optValue, value)
+		} else {
+			value, err = hook.OnBuildEntityModelDoc(ctx, path, optValue, value)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return value, nil
+}
@@ -0,0 +1,58 @@
+package bsondoc
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Lyearn/mgod/schema"
+)
+
+// arrayElemPathKey is the key by which array elements are represented in the schema tree paths.
+const arrayElemPathKey = "$"
+
+// getSchemaNodeForQueryField resolves the provided dotted field (as used in MongoDB queries) relative to the parent path
+// and returns the corresponding schema node along with its path. nil node is returned if the field is not present in the schema.
+//
+// Array elements can be referred to using an index (`tags.0`), the positional operators (`tags.$`, `tags.$[]`, `tags.$[elem]`)
+// or implicitly (`projects.projectId`), all of which resolve to the `$` node of the array in the schema tree.
+func getSchemaNodeForQueryField(field, parent string, schemaNodes map[string]*schema.TreeNode) (*schema.TreeNode, string) {
+	path := parent
+
+	for _, part := range strings.Split(field, ".") {
+		node, ok := schemaNodes[path]
+		if !ok {
+			return nil, path
+		}
+
+		if isArrayElemQueryKey(part) {
+			path = schema.GetPathForField(arrayElemPathKey, path)
+			continue
+		}
+
+		// implicit reference to the fields of array elements.
+		if node.Props.Type == reflect.Slice || node.Props.Type == reflect.Array {
+			path = schema.GetPathForField(arrayElemPathKey, path)
+		}
+
+		path = schema.GetPathForField(part, path)
+	}
+
+	node, ok := schemaNodes[path]
+	if !ok {
+		return nil, path
+	}
+
+	return node, path
+}
+
+// isArrayElemQueryKey reports whether the provided key of a dotted field refers to array element(s).
+func isArrayElemQueryKey(key string) bool {
+	if key == arrayElemPathKey || strings.HasPrefix(key, "$[") {
+		return true
+	}
+
+	_, err := strconv.Atoi(key)
+
+	return err == nil
+}
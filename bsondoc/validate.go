@@ -0,0 +1,68 @@
+package bsondoc
+
+import (
+	"strings"
+
+	"github.com/Lyearn/mgod/errors"
+	"github.com/Lyearn/mgod/schema"
+	"github.com/Lyearn/mgod/schema/fieldopt"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// validateNodeValue validates the provided value of a field against the validation rules of its schema node.
+// Value of an array elem node (e.g. pushed elements) is validated against the element rules of the array node.
+func validateNodeValue(value interface{}, schemaNodes map[string]*schema.TreeNode, path string) error {
+	schemaNode, ok := schemaNodes[path]
+	if !ok || value == nil {
+		return nil
+	}
+
+	if schemaNode.BSONKey == arrayElemPathKey {
+		arrayNode, ok := schemaNodes[strings.TrimSuffix(path, "."+arrayElemPathKey)]
+		if !ok {
+			return nil
+		}
+
+		return validateArrayElemValue(value, arrayNode)
+	}
+
+	for _, rule := range schemaNode.Props.Options.Validate {
+		arrayValue, isArray := value.(bson.A)
+
+		if rule.IsLengthRule() || !isArray {
+			if !rule.IsValid(value) {
+				return newValidationError(schemaNode.Path, rule, value)
+			}
+
+			continue
+		}
+
+		for _, elemValue := range arrayValue {
+			if elemValue != nil && !rule.IsValid(elemValue) {
+				return newValidationError(schemaNode.Path, rule, elemValue)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateArrayElemValue validates the provided element of the array represented by the provided node.
+func validateArrayElemValue(value interface{}, arrayNode *schema.TreeNode) error {
+	for _, rule := range arrayNode.Props.Options.Validate {
+		if !rule.IsLengthRule() && !rule.IsValid(value) {
+			return newValidationError(arrayNode.Path, rule, value)
+		}
+	}
+
+	return nil
+}
+
+func newValidationError(path string, rule fieldopt.ValidationRule, value interface{}) error {
+	return errors.ValidationError{
+		Path:  path,
+		Rule:  string(rule.Name),
+		Param: rule.Param,
+		Value: value,
+	}
+}
@@ -0,0 +1,163 @@
+package mgod_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lyearn/mgod"
+	"github.com/Lyearn/mgod/errors"
+	"github.com/Lyearn/mgod/schema/schemaopt"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type BulkWriteSuite struct {
+	suite.Suite
+	*require.Assertions
+
+	clientName string
+}
+
+func TestBulkWriteSuite(t *testing.T) {
+	s := new(BulkWriteSuite)
+	suite.Run(t, s)
+}
+
+func (s *BulkWriteSuite) SetupSuite() {
+	// bulk write models are converted without reaching the server, hence the client is never connected.
+	clientName, err := registerTestClient("bulkWrite")
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	s.clientName = clientName
+}
+
+func (s *BulkWriteSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *BulkWriteSuite) TearDownSuite() {
+	if err := mgod.DisconnectClient(context.Background(), s.clientName); err != nil {
+		s.T().Fatal(err)
+	}
+}
+
+func (s *BulkWriteSuite) TestInsertModels() {
+	entityMongoModel := newTestModelForClient(s.T(), s.clientName, testHookedEntity{}, "bulkWriteHooks", nil)
+
+	entity := testHookedEntity{ID: primitive.NewObjectID().Hex(), Name: "Gopher"}
+
+	bulkWrites := []mongo.WriteModel{mongo.NewInsertOneModel().SetDocument(entity)}
+	s.NoError(mgod.TransformToBulkWriteBSONDocs(context.Background(), entityMongoModel, bulkWrites))
+
+	insertOneModel, ok := bulkWrites[0].(*mongo.InsertOneModel)
+	s.True(ok)
+
+	doc, ok := insertOneModel.Document.(bson.D)
+	s.True(ok)
+	s.Contains(doc, bson.E{Key: "createdBy", Value: "gopher"})
+
+	// invalid entity is rejected by its Validate hook.
+	entity.Name = ""
+	bulkWrites = []mongo.WriteModel{mongo.NewInsertOneModel().SetDocument(entity)}
+	s.ErrorContains(mgod.TransformToBulkWriteBSONDocs(context.Background(), entityMongoModel, bulkWrites), "name is required")
+}
+
+func (s *BulkWriteSuite) TestSoftDeleteModels() {
+	schemaOpts := schemaopt.SchemaOptions{SoftDelete: true}
+	entityMongoModel := newTestModelForClient(s.T(), s.clientName, testEntity{}, "bulkWriteSoftDelete", &schemaOpts)
+
+	bulkWrites := []mongo.WriteModel{
+		mongo.NewDeleteOneModel().SetFilter(bson.M{"name": "Gopher"}),
+		mongo.NewDeleteManyModel().SetFilter(bson.M{"name": "Gopher"}).SetHint("name_1"),
+	}
+
+	s.NoError(mgod.TransformToBulkWriteBSONDocs(context.Background(), entityMongoModel, bulkWrites))
+
+	softDeleteUpdate := bson.D{{Key: "$currentDate", Value: bson.D{{Key: "deletedAt", Value: true}}}}
+	notDeletedFilter := bson.M{"name": "Gopher", "deletedAt": nil}
+
+	updateOneModel, ok := bulkWrites[0].(*mongo.UpdateOneModel)
+	s.True(ok)
+	s.Equal(notDeletedFilter, updateOneModel.Filter)
+	s.Equal(softDeleteUpdate, updateOneModel.Update)
+
+	updateManyModel, ok := bulkWrites[1].(*mongo.UpdateManyModel)
+	s.True(ok)
+	s.Equal(notDeletedFilter, updateManyModel.Filter)
+	s.Equal(softDeleteUpdate, updateManyModel.Update)
+	s.Equal("name_1", updateManyModel.Hint)
+}
+
+func (s *BulkWriteSuite) TestTenantInsertModels() {
+	schemaOpts := schemaopt.SchemaOptions{
+		Tenancy: &schemaopt.TenancyOptions{
+			Resolver: func(ctx context.Context) (string, error) {
+				tenant, _ := ctx.Value(testTenantCtxKey{}).(string)
+				return tenant, nil
+			},
+		},
+	}
+	entityMongoModel := newTestModelForClient(s.T(), s.clientName, testEntity{}, "bulkWriteTenancy", &schemaOpts)
+
+	ctx := context.WithValue(context.Background(), testTenantCtxKey{}, "tenant1")
+	tenantElem := bson.E{Key: "tenantId", Value: "tenant1"}
+
+	bulkWrites := []mongo.WriteModel{
+		mongo.NewInsertOneModel().SetDocument(testEntity{ID: primitive.NewObjectID().Hex(), Name: "Gopher"}),
+		mongo.NewInsertOneModel().SetDocument(bson.D{{Key: "name", Value: "Gopher"}}),
+		mongo.NewInsertOneModel().SetDocument(bson.M{"name": "Gopher", "tenantId": "tenant1"}),
+		mongo.NewReplaceOneModel().SetFilter(bson.M{}).SetReplacement(bson.D{{Key: "name", Value: "Gopher"}}),
+	}
+	s.NoError(mgod.TransformToBulkWriteBSONDocs(ctx, entityMongoModel, bulkWrites))
+
+	for _, bulkWrite := range bulkWrites[:3] {
+		insertOneModel, ok := bulkWrite.(*mongo.InsertOneModel)
+		s.True(ok)
+		s.Contains(insertOneModel.Document, tenantElem)
+	}
+
+	replaceOneModel, ok := bulkWrites[3].(*mongo.ReplaceOneModel)
+	s.True(ok)
+	s.Contains(replaceOneModel.Replacement, tenantElem)
+
+	// docs of the other tenants are rejected.
+	for _, doc := range []interface{}{bson.D{{Key: "tenantId", Value: "tenant2"}}, bson.M{"tenantId": "tenant2"}} {
+		bulkWrites = []mongo.WriteModel{
+			mongo.NewInsertOneModel().SetDocument(testEntity{ID: primitive.NewObjectID().Hex(), Name: "Gopher"}),
+			mongo.NewInsertOneModel().SetDocument(doc),
+		}
+		s.ErrorIs(mgod.TransformToBulkWriteBSONDocs(ctx, entityMongoModel, bulkWrites), errors.ErrCrossTenantWrite)
+	}
+
+	_, err := entityMongoModel.InsertOne(ctx, bson.D{{Key: "tenantId", Value: "tenant2"}})
+	s.ErrorIs(err, errors.ErrCrossTenantWrite)
+
+	_, err = entityMongoModel.InsertMany(ctx, []bson.D{{{Key: "tenantId", Value: "tenant2"}}})
+	s.ErrorIs(err, errors.ErrCrossTenantWrite)
+}
+
+func (s *BulkWriteSuite) TestVersionedReplaceModels() {
+	schemaOpts := schemaopt.SchemaOptions{OptimisticConcurrency: true}
+	entityMongoModel := newTestModelForClient(s.T(), s.clientName, testVersionedEntity{}, "bulkWriteVersioned", &schemaOpts)
+
+	entity := testVersionedEntity{ID: primitive.NewObjectID().Hex(), Name: "Gopher", Version: 2}
+
+	bulkWrites := []mongo.WriteModel{mongo.NewReplaceOneModel().SetFilter(bson.M{"name": "Gopher"}).SetReplacement(entity)}
+	s.NoError(mgod.TransformToBulkWriteBSONDocs(context.Background(), entityMongoModel, bulkWrites))
+
+	replaceOneModel, ok := bulkWrites[0].(*mongo.ReplaceOneModel)
+	s.True(ok)
+	s.Equal(bson.D{{Key: "$and", Value: bson.A{bson.M{"name": "Gopher"}, bson.D{{Key: "__v", Value: int32(2)}}}}}, replaceOneModel.Filter)
+	s.Contains(replaceOneModel.Replacement, bson.E{Key: "__v", Value: 3})
+
+	// version of a replacement doc which is not an entity model is unknown.
+	bulkWrites = []mongo.WriteModel{mongo.NewReplaceOneModel().SetFilter(bson.M{}).SetReplacement(bson.D{{Key: "name", Value: "Gopher"}})}
+
+	var badRequestErr errors.BadRequestError
+	s.ErrorAs(mgod.TransformToBulkWriteBSONDocs(context.Background(), entityMongoModel, bulkWrites), &badRequestErr)
+}
@@ -2,13 +2,28 @@ package mgod
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
+	"github.com/Lyearn/mgod/errors"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-var mClient *mongo.Client
+// DefaultClientName is the name of the default MongoDB client i.e. the client set using SetDefaultClient or
+// ConfigureDefaultClient. Models use the default client unless a client name is provided in the model options.
+const DefaultClientName = "default"
+
+var (
+	clientsMu sync.RWMutex
+	// clients are the MongoDB clients used by the package keyed by their name.
+	clients = map[string]*mongo.Client{}
+	// clientsMonitors are the monitors of the clients set using SetDefaultClient or ConfigureDefaultClient keyed by the
+	// client name. They are removed when the client is replaced.
+	clientsMonitors = map[string]clientMonitors{}
+)
+
 var defaultTimeout = 10 * time.Second
 
 // ConnectionConfig is the configuration options available for a MongoDB connection.
@@ -17,32 +32,176 @@ type ConnectionConfig struct {
 	Timeout time.Duration
 }
 
-// SetDefaultClient sets the default MongoDB client to be used by the package.
+// SetDefaultClient sets the default MongoDB client to be used by the package. A replaced default client is not
+// disconnected, but its cached database connections, pool statistics and tracked topology are removed.
 func SetDefaultClient(client *mongo.Client) {
-	mClient = client
+	setClient(DefaultClientName, client, nil)
 }
 
 // ConfigureDefaultClient opens a new connection using the provided config options and sets the default MongoDB client to be used by the package.
-func ConfigureDefaultClient(cfg *ConnectionConfig, opts ...*options.ClientOptions) (err error) {
+func ConfigureDefaultClient(cfg *ConnectionConfig, opts ...*options.ClientOptions) error {
+	client, monitors, err := connectClient(cfg, opts...)
+	if err != nil {
+		return err
+	}
+
+	setClient(DefaultClientName, client, monitors)
+
+	return nil
+}
+
+// RegisterClient adds a named MongoDB client to be used by the models created with the same client name
+// (see [entityMongoModelOptions.SetClientName]). It returns an error if a client is already registered with the name.
+func RegisterClient(name string, client *mongo.Client) error {
+	if name == "" || client == nil {
+		return errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: "client registration",
+			Got:        "empty name or nil client",
+			Expected:   "client with non empty name",
+		})
+	}
+
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+
+	if _, ok := clients[name]; ok {
+		return errors.NewAlreadyExistsError(errors.AlreadyExistsError{
+			Underlying: "client registry",
+			Value:      name,
+		})
+	}
+
+	clients[name] = client
+
+	return nil
+}
+
+// ConfigureClient opens a new connection using the provided config options and registers it as a named MongoDB client.
+// See [RegisterClient] for more details.
+func ConfigureClient(name string, cfg *ConnectionConfig, opts ...*options.ClientOptions) error {
+	if _, err := getClient(name); err == nil {
+		return errors.NewAlreadyExistsError(errors.AlreadyExistsError{
+			Underlying: "client registry",
+			Value:      name,
+		})
+	}
+
+	client, monitors, err := connectClient(cfg, opts...)
+	if err != nil {
+		return err
+	}
+
+	// the name can be registered by a concurrent caller while connecting. hence, the new client is discarded
+	// without touching the monitored stats of the registered one.
+	if err = RegisterClient(name, client); err != nil {
+		_ = client.Disconnect(context.Background())
+		return err
+	}
+
+	monitors.publish(name)
+
+	return nil
+}
+
+// setClient sets the MongoDB client for the provided name and publishes the provided monitors (if any) of the client.
+// If a client is replaced, its cached database connections are removed along with its pool statistics and tracked
+// topology, unless they have already been replaced by the monitors of the new client (see [NewPoolMonitor]).
+func setClient(name string, client *mongo.Client, monitors *clientMonitors) {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+
+	if replacedClient, ok := clients[name]; ok && replacedClient != client {
+		replacedMonitors := clientsMonitors[name]
+
+		dbConnCache.DeleteClient(name)
+		deleteReplacedPoolStats(name, replacedMonitors.poolStats)
+		deleteReplacedTopology(name, replacedMonitors.topology)
+	}
+
+	clients[name] = client
+
+	if monitors != nil {
+		monitors.publish(name)
+	}
+
+	clientsMonitors[name] = clientMonitors{
+		poolStats: getPoolStatsCollector(name),
+		topology:  getTopologyTracker(name),
+	}
+}
+
+// getClient returns the MongoDB client registered with the provided name.
+func getClient(name string) (*mongo.Client, error) {
+	clientsMu.RLock()
+	defer clientsMu.RUnlock()
+
+	client, ok := clients[name]
+	if !ok || client == nil {
+		return nil, newClientNotFoundError(name)
+	}
+
+	return client, nil
+}
+
+// newClientNotFoundError returns the error of a missing client with the provided name.
+func newClientNotFoundError(name string) error {
+	if name == DefaultClientName {
+		return errors.ErrNoDatabaseConnection
+	}
+
+	return errors.NewNotFoundError(errors.NotFoundError{
+		Underlying: "client registry",
+		Value:      fmt.Sprintf("client - %s", name),
+	})
+}
+
+// clientMonitors are the collectors of the connection pool statistics (see [Stats]) and the topology
+// (see [HealthCheck]) of a client configured by mgod.
+type clientMonitors struct {
+	poolStats *poolStatsCollector
+	topology  *topologyTracker
+}
+
+// publish makes the collected statistics and topology available under the provided client name. It is called only
+// once the client is set so that a failed configuration doesn't affect the client already using the name.
+func (m *clientMonitors) publish(clientName string) {
+	setPoolStats(clientName, m.poolStats)
+	setTopologyTracker(clientName, m.topology)
+}
+
+// connectClient opens a new connection using the provided config options and pings the MongoDB server.
+// Connection pool statistics and the topology of the client are collected by the returned monitors.
+func connectClient(cfg *ConnectionConfig, opts ...*options.ClientOptions) (*mongo.Client, *clientMonitors, error) {
 	if cfg == nil {
 		cfg = defaultConnectionConfig()
 	}
 
-	mClient, err = newClient(cfg, opts...)
+	monitors := &clientMonitors{
+		poolStats: newPoolStatsCollector(),
+		topology:  &topologyTracker{},
+	}
+
+	mergedOpts := options.MergeClientOptions(opts...)
+	opts = append(opts[:len(opts):len(opts)], options.Client().
+		SetPoolMonitor(monitors.poolStats.poolMonitor(mergedOpts.PoolMonitor)).
+		SetServerMonitor(monitors.topology.serverMonitor(mergedOpts.ServerMonitor)))
+
+	client, err := newClient(cfg, opts...)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	ctx, cancel := newCtx(cfg.Timeout)
 	defer cancel()
 
 	// Ping the MongoDB server to check if connection is established.
-	err = mClient.Ping(ctx, nil)
+	err = client.Ping(ctx, nil)
 	if err != nil {
-		return err
+		_ = client.Disconnect(context.Background())
+		return nil, nil, err
 	}
 
-	return nil
+	return client, monitors, nil
 }
 
 // newClient creates a new MongoDB client.
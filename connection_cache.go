@@ -2,6 +2,7 @@ package mgod
 
 import (
 	"sync"
+	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -13,42 +14,123 @@ func init() {
 	dbConnCache = newConnectionCache()
 }
 
+// SetDBConnIdleTimeout sets the duration after which the cached database connections which are not used are evicted
+// from the connection cache. It is helpful for the models using a db resolver (see [entityMongoModelOptions.SetDBResolver])
+// with a large number of databases e.g. database-per-tenant. Defaults to 0 i.e. connections are never evicted.
+func SetDBConnIdleTimeout(timeout time.Duration) {
+	dbConnCache.SetIdleTimeout(timeout)
+}
+
 // connectionCache is a thread safe construct to cache MongoDB database connections.
 type connectionCache struct {
-	cache map[string]*mongo.Database
-	mux   sync.RWMutex
+	cache map[connectionCacheKey]*connectionCacheEntry
+	mux   sync.Mutex
+
+	// idleTimeout is the duration after which an unused entry is evicted. Zero value disables the eviction.
+	idleTimeout time.Duration
+	// lastEvictedAt is the time of the last eviction sweep. Sweeps are performed lazily on access.
+	lastEvictedAt time.Time
+}
+
+// connectionCacheKey identifies a database of a MongoDB client.
+type connectionCacheKey struct {
+	clientName string
+	dbName     string
+}
+
+type connectionCacheEntry struct {
+	db         *mongo.Database
+	lastUsedAt time.Time
 }
 
 func newConnectionCache() *connectionCache {
 	return &connectionCache{
-		cache: map[string]*mongo.Database{},
+		cache: map[connectionCacheKey]*connectionCacheEntry{},
+	}
+}
+
+func (c *connectionCache) Get(clientName, dbName string) *mongo.Database {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.evictIdle()
+
+	entry, ok := c.cache[connectionCacheKey{clientName: clientName, dbName: dbName}]
+	if !ok {
+		return nil
+	}
+
+	entry.lastUsedAt = time.Now()
+
+	return entry.db
+}
+
+func (c *connectionCache) Set(clientName, dbName string, db *mongo.Database) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.cache[connectionCacheKey{clientName: clientName, dbName: dbName}] = &connectionCacheEntry{
+		db:         db,
+		lastUsedAt: time.Now(),
 	}
 }
 
-func (c *connectionCache) Get(dbName string) *mongo.Database {
-	c.mux.RLock()
-	defer c.mux.RUnlock()
+// DeleteClient removes the cached connections of the provided client.
+func (c *connectionCache) DeleteClient(clientName string) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
 
-	return c.cache[dbName]
+	for key := range c.cache {
+		if key.clientName == clientName {
+			delete(c.cache, key)
+		}
+	}
 }
 
-func (c *connectionCache) Set(dbName string, db *mongo.Database) {
+func (c *connectionCache) SetIdleTimeout(timeout time.Duration) {
 	c.mux.Lock()
 	defer c.mux.Unlock()
 
-	c.cache[dbName] = db
+	c.idleTimeout = timeout
 }
 
-// getDBConn returns a MongoDB database connection from the cache.
+// evictIdle removes the entries which are not used for the idle timeout. Entries are swept at most once per
+// idle timeout to keep the cache access cheap. It must be called with the lock held.
+func (c *connectionCache) evictIdle() {
+	if c.idleTimeout <= 0 {
+		return
+	}
+
+	now := time.Now()
+	if now.Sub(c.lastEvictedAt) < c.idleTimeout {
+		return
+	}
+
+	for key, entry := range c.cache {
+		if now.Sub(entry.lastUsedAt) >= c.idleTimeout {
+			delete(c.cache, key)
+		}
+	}
+
+	c.lastEvictedAt = now
+}
+
+// getDBConn returns a MongoDB database connection of the provided client from the cache.
 // If the connection is not present in the cache, it creates a new connection and adds it to the cache (Write-through policy).
-func getDBConn(dbName string) *mongo.Database {
-	dbConn := dbConnCache.Get(dbName)
+// It returns an error if the client is not registered.
+func getDBConn(clientName, dbName string) (*mongo.Database, error) {
+	dbConn := dbConnCache.Get(clientName, dbName)
 
 	// Initialize the cache entry if it is not present.
 	if dbConn == nil {
-		dbConn = mClient.Database(dbName)
-		dbConnCache.Set(dbName, dbConn)
+		client, err := getClient(clientName)
+		if err != nil {
+			return nil, err
+		}
+
+		dbConn = client.Database(dbName)
+		dbConnCache.Set(clientName, dbName, dbConn)
 	}
 
-	return dbConn
+	return dbConn, nil
 }
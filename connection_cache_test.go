@@ -0,0 +1,61 @@
+package mgod_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lyearn/mgod"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type testDBCtxKey struct{}
+
+type DBResolverSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func TestDBResolverSuite(t *testing.T) {
+	s := new(DBResolverSuite)
+	suite.Run(t, s)
+}
+
+func (s *DBResolverSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *DBResolverSuite) TestDBResolver() {
+	connectTestClient(s.T())
+
+	opts := mgod.NewEntityMongoModelOptions(testDBName, "entityMongoModelDBResolver", nil).
+		SetDBResolver(func(ctx context.Context) string {
+			dbName, _ := ctx.Value(testDBCtxKey{}).(string)
+			return dbName
+		})
+	entityMongoModel, err := mgod.NewEntityMongoModel(testEntity{}, *opts)
+	s.NoError(err)
+
+	tenant1Ctx := context.WithValue(context.Background(), testDBCtxKey{}, "mgoddb_tenant1")
+	tenant2Ctx := context.WithValue(context.Background(), testDBCtxKey{}, "mgoddb_tenant2")
+
+	for _, ctx := range []context.Context{tenant1Ctx, tenant2Ctx, context.Background()} {
+		_, err = entityMongoModel.DeleteMany(ctx, bson.M{})
+		s.NoError(err)
+	}
+
+	_, err = entityMongoModel.InsertOne(tenant1Ctx, testEntity{Name: "Gopher"})
+	s.NoError(err)
+
+	count, err := entityMongoModel.CountDocuments(tenant1Ctx, bson.M{"name": "Gopher"})
+	s.NoError(err)
+	s.Equal(int64(1), count)
+
+	// other databases, including the default one, don't hold the doc.
+	for _, ctx := range []context.Context{tenant2Ctx, context.Background()} {
+		count, err = entityMongoModel.CountDocuments(ctx, bson.M{"name": "Gopher"})
+		s.NoError(err)
+		s.Equal(int64(0), count)
+	}
+}
@@ -0,0 +1,92 @@
+package mgod
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Lyearn/mgod/schema"
+	"github.com/samber/lo"
+)
+
+var (
+	clientSchemasMu sync.Mutex
+	// clientSchemas are the schema cache keys of the models created for a client keyed by the client name.
+	clientSchemas = map[string]map[string]struct{}{}
+)
+
+// Disconnect disconnects all the registered clients (including the default one). See [DisconnectClient] for more details.
+// It is meant to be called during the graceful shutdown of the service.
+func Disconnect(ctx context.Context) error {
+	clientsMu.RLock()
+	clientNames := lo.Keys(clients)
+	clientsMu.RUnlock()
+
+	var disconnectErr error
+
+	for _, clientName := range clientNames {
+		if err := DisconnectClient(ctx, clientName); err != nil && disconnectErr == nil {
+			disconnectErr = err
+		}
+	}
+
+	return disconnectErr
+}
+
+// DisconnectClient closes the connections of the provided client and removes it from the package along with its
+// cached database connections, pool statistics, tracked topology and the cached schemas of its models (unless used by another client).
+// Models created for the client can't be used after disconnecting it.
+func DisconnectClient(ctx context.Context, clientName string) error {
+	// client is looked up and removed at once, so that only one of the concurrent callers disconnects it.
+	clientsMu.Lock()
+	client, ok := clients[clientName]
+	delete(clients, clientName)
+	delete(clientsMonitors, clientName)
+	clientsMu.Unlock()
+
+	if !ok || client == nil {
+		return newClientNotFoundError(clientName)
+	}
+
+	dbConnCache.DeleteClient(clientName)
+	deletePoolStats(clientName)
+	deleteTopology(clientName)
+	deleteClientSchemas(clientName)
+
+	return client.Disconnect(ctx)
+}
+
+// trackClientSchema records that the schema cached with the provided key is used by a model of the provided client.
+func trackClientSchema(clientName, schemaCacheKey string) {
+	clientSchemasMu.Lock()
+	defer clientSchemasMu.Unlock()
+
+	if clientSchemas[clientName] == nil {
+		clientSchemas[clientName] = map[string]struct{}{}
+	}
+
+	clientSchemas[clientName][schemaCacheKey] = struct{}{}
+}
+
+// deleteClientSchemas removes the cached schemas used by the models of the provided client which are not used by
+// the models of any other client.
+func deleteClientSchemas(clientName string) {
+	clientSchemasMu.Lock()
+	defer clientSchemasMu.Unlock()
+
+	schemaCacheKeys := clientSchemas[clientName]
+	delete(clientSchemas, clientName)
+
+	for schemaCacheKey := range schemaCacheKeys {
+		isUsedByOtherClient := false
+		for _, otherSchemaCacheKeys := range clientSchemas {
+			if _, ok := otherSchemaCacheKeys[schemaCacheKey]; ok {
+				isUsedByOtherClient = true
+				break
+			}
+		}
+
+		if !isUsedByOtherClient {
+			schema.EntityModelSchemaCacheInstance.DeleteSchema(schemaCacheKey)
+		}
+	}
+}
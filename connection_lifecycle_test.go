@@ -0,0 +1,170 @@
+package mgod_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Lyearn/mgod"
+	"github.com/Lyearn/mgod/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ConnectionLifecycleSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func TestConnectionLifecycleSuite(t *testing.T) {
+	s := new(ConnectionLifecycleSuite)
+	suite.Run(t, s)
+}
+
+func (s *ConnectionLifecycleSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *ConnectionLifecycleSuite) registerClient() string {
+	clientName, err := registerTestClient("lifecycle")
+	s.NoError(err)
+
+	return clientName
+}
+
+func (s *ConnectionLifecycleSuite) TestOperationsAfterDisconnect() {
+	clientName := s.registerClient()
+
+	opts := mgod.NewEntityMongoModelOptions("mgoddb", "connectionLifecycle", nil).SetClientName(clientName)
+	entityMongoModel, err := mgod.NewEntityMongoModel(testEntity{}, *opts)
+	s.NoError(err)
+
+	s.NoError(mgod.DisconnectClient(context.Background(), clientName))
+
+	s.NotPanics(func() {
+		_, err = entityMongoModel.Find(context.Background(), bson.M{})
+	})
+	s.ErrorContains(err, clientName)
+
+	s.NotPanics(func() {
+		_, err = entityMongoModel.InsertOne(context.Background(), testEntity{Name: "Gopher"})
+	})
+	s.Error(err)
+
+	s.NotPanics(func() {
+		err = entityMongoModel.EnsureIndexes(context.Background())
+	})
+	s.Error(err)
+}
+
+func (s *ConnectionLifecycleSuite) TestUnknownClientName() {
+	opts := mgod.NewEntityMongoModelOptions("mgoddb", "connectionLifecycle", nil).SetClientName("unknown")
+	_, err := mgod.NewEntityMongoModel(testEntity{}, *opts)
+	s.ErrorIs(err, errors.ErrNotFound)
+
+	var notFoundErr errors.NotFoundError
+	s.ErrorAs(err, &notFoundErr)
+	s.Equal("client - unknown", notFoundErr.Value)
+
+	_, err = mgod.NewClientMongoResumeTokenStore("unknown", "mgoddb", "resumeTokens")
+	s.ErrorContains(err, "unknown")
+
+	clientName := s.registerClient()
+	_, err = mgod.NewEntityMongoModel(testEntity{}, *opts.SetClientName(clientName))
+	s.NoError(err)
+
+	_, err = mgod.NewClientMongoResumeTokenStore(clientName, "mgoddb", "resumeTokens")
+	s.NoError(err)
+
+	s.NoError(mgod.DisconnectClient(context.Background(), clientName))
+}
+
+func (s *ConnectionLifecycleSuite) TestConfigureClientFailure() {
+	clientName := fmt.Sprintf("unreachable_%s", primitive.NewObjectID().Hex())
+
+	err := mgod.ConfigureClient(clientName, &mgod.ConnectionConfig{Timeout: time.Second}, options.Client().
+		ApplyURI("mongodb://localhost:1").
+		SetServerSelectionTimeout(500*time.Millisecond))
+	s.Error(err)
+
+	// a failed configuration neither registers the client nor publishes its stats.
+	s.NotContains(mgod.Stats(), clientName)
+	s.NotContains(mgod.HealthCheck(context.Background()), clientName)
+	s.ErrorContains(mgod.DisconnectClient(context.Background(), clientName), clientName)
+}
+
+func (s *ConnectionLifecycleSuite) TestConfigureClientWithRegisteredName() {
+	clientName := s.registerClient()
+
+	opts := mgod.NewEntityMongoModelOptions("mgoddb", "connectionLifecycle", nil).SetClientName(clientName)
+	_, err := mgod.NewEntityMongoModel(testEntity{}, *opts)
+	s.NoError(err)
+
+	err = mgod.ConfigureClient(clientName, nil, options.Client().ApplyURI("mongodb://localhost:27017"))
+	s.ErrorIs(err, errors.ErrAlreadyExists)
+	s.NotContains(mgod.Stats(), clientName)
+
+	var alreadyExistsErr errors.AlreadyExistsError
+	s.ErrorAs(err, &alreadyExistsErr)
+	s.Equal(clientName, alreadyExistsErr.Value)
+
+	// the registered client is left intact.
+	_, err = mgod.NewEntityMongoModel(testEntity{}, *opts)
+	s.NoError(err)
+
+	s.NoError(mgod.DisconnectClient(context.Background(), clientName))
+}
+
+func (s *ConnectionLifecycleSuite) TestReplaceClient() {
+	clientName := fmt.Sprintf("lifecycle_%s", primitive.NewObjectID().Hex())
+	newClient := func(poolMonitor *event.PoolMonitor) *mongo.Client {
+		client, err := mongo.Connect(context.Background(), options.Client().
+			ApplyURI("mongodb://localhost:27017").
+			SetServerSelectionTimeout(time.Second).
+			SetPoolMonitor(poolMonitor))
+		s.NoError(err)
+
+		return client
+	}
+
+	mgod.SetClient(clientName, newClient(mgod.NewPoolMonitor(clientName, nil)))
+	s.Contains(mgod.Stats(), clientName)
+
+	// pool statistics of the replaced client are removed.
+	mgod.SetClient(clientName, newClient(nil))
+	s.NotContains(mgod.Stats(), clientName)
+
+	// pool statistics of the new client are kept.
+	mgod.SetClient(clientName, newClient(mgod.NewPoolMonitor(clientName, nil)))
+	s.Contains(mgod.Stats(), clientName)
+
+	s.NoError(mgod.DisconnectClient(context.Background(), clientName))
+	s.NotContains(mgod.Stats(), clientName)
+}
+
+func (s *ConnectionLifecycleSuite) TestConcurrentDisconnect() {
+	clientName := s.registerClient()
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			errs <- mgod.DisconnectClient(context.Background(), clientName)
+		}()
+	}
+
+	var notFoundErrs int
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			s.ErrorIs(err, errors.ErrNotFound)
+			notFoundErrs++
+		}
+	}
+
+	s.Equal(1, notFoundErrs)
+}
@@ -0,0 +1,70 @@
+package mgod_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Lyearn/mgod"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ClientRegistrySuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func TestClientRegistrySuite(t *testing.T) {
+	s := new(ClientRegistrySuite)
+	suite.Run(t, s)
+}
+
+func (s *ClientRegistrySuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *ClientRegistrySuite) TestNamedClient() {
+	client, err := mongo.Connect(context.Background(),
+		options.Client().ApplyURI("mongodb://localhost:27017/?replicaSet=replset&authSource=admin"))
+	s.NoError(err)
+
+	clientName := fmt.Sprintf("analytics_%s", primitive.NewObjectID().Hex())
+	s.NoError(mgod.RegisterClient(clientName, client))
+	s.Error(mgod.RegisterClient(clientName, client))
+
+	_, err = mgod.NewEntityMongoModel(testEntity{}, *mgod.NewEntityMongoModelOptions("mgoddb", "entityMongoModelNamedClient", nil).
+		SetClientName("unknown"))
+	s.Error(err)
+
+	opts := mgod.NewEntityMongoModelOptions("mgoddb", "entityMongoModelNamedClient", nil).SetClientName(clientName)
+	entityMongoModel, err := mgod.NewEntityMongoModel(testEntity{}, *opts)
+	s.NoError(err)
+
+	_, err = mgod.WithClientTransaction(context.Background(), clientName, func(sc mongo.SessionContext) (interface{}, error) {
+		return entityMongoModel.InsertOne(sc, testEntity{Name: "Gopher"})
+	})
+	s.NoError(err)
+
+	result, err := entityMongoModel.DeleteMany(context.Background(), bson.M{"name": "Gopher"})
+	s.NoError(err)
+	s.Equal(int64(1), result.DeletedCount)
+
+	store, err := mgod.NewClientMongoResumeTokenStore(clientName, "mgoddb", "resumeTokens")
+	s.NoError(err)
+
+	resumeToken, err := bson.Marshal(bson.M{"_data": "token"})
+	s.NoError(err)
+	s.NoError(store.SaveResumeToken(context.Background(), clientName, resumeToken))
+
+	storedResumeToken, err := store.GetResumeToken(context.Background(), clientName)
+	s.NoError(err)
+	s.Equal(bson.Raw(resumeToken), storedResumeToken)
+
+	s.NoError(mgod.DisconnectClient(context.Background(), clientName))
+	s.Error(mgod.DisconnectClient(context.Background(), clientName))
+}
@@ -0,0 +1,213 @@
+package mgod
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ChangeEvent is a typed change event returned by a change stream of an EntityMongoModel.
+// FullDocument and FullDocumentBeforeChange are translated to the entity model in the same way as a found doc
+// and are nil if not present in the event (e.g. FullDocument of a delete event).
+type ChangeEvent[T any] struct {
+	ResumeToken              bson.Raw
+	OperationType            string
+	ClusterTime              primitive.Timestamp
+	DocumentKey              bson.D
+	UpdateDescription        *ChangeEventUpdateDescription
+	FullDocument             *T
+	FullDocumentBeforeChange *T
+}
+
+// ChangeEventUpdateDescription describes the fields updated or removed by an update event.
+// Updated fields are kept in the MongoDB representation as they are not complete docs.
+type ChangeEventUpdateDescription struct {
+	UpdatedFields   bson.D   `bson:"updatedFields"`
+	RemovedFields   []string `bson:"removedFields"`
+	TruncatedArrays bson.A   `bson:"truncatedArrays"`
+}
+
+// changeEventDoc is the raw change event doc returned by MongoDB.
+type changeEventDoc struct {
+	OperationType            string                        `bson:"operationType"`
+	ClusterTime              primitive.Timestamp           `bson:"clusterTime"`
+	DocumentKey              bson.D                        `bson:"documentKey"`
+	UpdateDescription        *ChangeEventUpdateDescription `bson:"updateDescription"`
+	FullDocument             bson.D                        `bson:"fullDocument"`
+	FullDocumentBeforeChange bson.D                        `bson:"fullDocumentBeforeChange"`
+}
+
+// WatchOptions are the options available for the Watch operation of an EntityMongoModel.
+type WatchOptions struct {
+	changeStreamOpts *options.ChangeStreamOptions
+	resumeTokenStore ResumeTokenStore
+	streamName       string
+}
+
+// NewWatchOptions creates a new WatchOptions instance.
+func NewWatchOptions() *WatchOptions {
+	return &WatchOptions{}
+}
+
+// SetChangeStreamOptions sets the options of the underlying MongoDB change stream.
+func (o *WatchOptions) SetChangeStreamOptions(opts *options.ChangeStreamOptions) *WatchOptions {
+	o.changeStreamOpts = opts
+	return o
+}
+
+// SetResumeTokenStore sets the store used to persist the resume token of the change stream against the provided
+// stream name. If a token is already stored for the stream, the change stream resumes after it, unless a resume point
+// is explicitly provided in the change stream options.
+func (o *WatchOptions) SetResumeTokenStore(store ResumeTokenStore, streamName string) *WatchOptions {
+	o.resumeTokenStore = store
+	o.streamName = streamName
+	return o
+}
+
+// EntityMongoChangeStream is a typed iterator over the change events of a collection.
+type EntityMongoChangeStream[T any] interface {
+	// Next blocks until the next change event is available. It returns false if there was an error or the change stream
+	// has been closed. If a resume token store is configured, the resume token of the previous event is saved before
+	// waiting for the next one i.e. an event is considered processed once the next event is requested.
+	Next(ctx context.Context) bool
+
+	// Decode translates the current change event of the change stream.
+	Decode() (ChangeEvent[T], error)
+
+	// SaveResumeToken saves the resume token of the current change event in the configured resume token store.
+	// It is a no-op if no store is configured.
+	SaveResumeToken(ctx context.Context) error
+
+	// ResumeToken returns the last cached resume token of the change stream.
+	ResumeToken() bson.Raw
+
+	// Err returns the last error seen by the change stream, or nil if no error has occurred.
+	Err() error
+
+	// Close closes the change stream.
+	Close(ctx context.Context) error
+}
+
+type entityMongoChangeStream[T any] struct {
+	model  entityMongoModel[T]
+	stream *mongo.ChangeStream
+
+	resumeTokenStore ResumeTokenStore
+	streamName       string
+
+	// hasCurrent reports whether the change stream points to an event whose token is not saved yet.
+	hasCurrent bool
+	// err is the last error returned by the resume token store.
+	err error
+
+	// ctx is the context provided in the last call to Next. It is used to build the entity models in Decode.
+	ctx context.Context
+}
+
+// getChangeStreamOptions returns a copy of the change stream options with the stored resume token added to it.
+func (o *WatchOptions) getChangeStreamOptions(ctx context.Context) (*options.ChangeStreamOptions, error) {
+	changeStreamOpts := options.MergeChangeStreamOptions(o.changeStreamOpts)
+
+	hasResumePoint := changeStreamOpts.ResumeAfter != nil ||
+		changeStreamOpts.StartAfter != nil ||
+		changeStreamOpts.StartAtOperationTime != nil
+
+	if o.resumeTokenStore == nil || hasResumePoint {
+		return changeStreamOpts, nil
+	}
+
+	resumeToken, err := o.resumeTokenStore.GetResumeToken(ctx, o.streamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if resumeToken != nil {
+		changeStreamOpts.SetResumeAfter(resumeToken)
+	}
+
+	return changeStreamOpts, nil
+}
+
+func (s *entityMongoChangeStream[T]) Next(ctx context.Context) bool {
+	s.ctx = ctx
+
+	if s.hasCurrent {
+		if s.err = s.SaveResumeToken(ctx); s.err != nil {
+			return false
+		}
+	}
+
+	s.hasCurrent = s.stream.Next(ctx)
+
+	return s.hasCurrent
+}
+
+func (s *entityMongoChangeStream[T]) Decode() (ChangeEvent[T], error) {
+	event := ChangeEvent[T]{
+		ResumeToken: s.stream.ResumeToken(),
+	}
+
+	var doc changeEventDoc
+	if err := s.stream.Decode(&doc); err != nil {
+		return event, err
+	}
+
+	event.OperationType = doc.OperationType
+	event.ClusterTime = doc.ClusterTime
+	event.DocumentKey = doc.DocumentKey
+	event.UpdateDescription = doc.UpdateDescription
+
+	var err error
+
+	if event.FullDocument, err = s.decodeFullDocument(doc.FullDocument); err != nil {
+		return event, err
+	}
+
+	if event.FullDocumentBeforeChange, err = s.decodeFullDocument(doc.FullDocumentBeforeChange); err != nil {
+		return event, err
+	}
+
+	return event, nil
+}
+
+func (s *entityMongoChangeStream[T]) decodeFullDocument(doc bson.D) (*T, error) {
+	if doc == nil {
+		//nolint:nilnil // full document is not present in the event
+		return nil, nil
+	}
+
+	model, err := s.model.getEntityModelFromFoundDoc(s.ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model, nil
+}
+
+func (s *entityMongoChangeStream[T]) SaveResumeToken(ctx context.Context) error {
+	resumeToken := s.stream.ResumeToken()
+	if s.resumeTokenStore == nil || resumeToken == nil {
+		return nil
+	}
+
+	return s.resumeTokenStore.SaveResumeToken(ctx, s.streamName, resumeToken)
+}
+
+func (s *entityMongoChangeStream[T]) ResumeToken() bson.Raw {
+	return s.stream.ResumeToken()
+}
+
+func (s *entityMongoChangeStream[T]) Err() error {
+	if s.err != nil {
+		return s.err
+	}
+
+	return s.stream.Err()
+}
+
+func (s *entityMongoChangeStream[T]) Close(ctx context.Context) error {
+	return s.stream.Close(ctx)
+}
@@ -0,0 +1,108 @@
+package mgod_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lyearn/mgod"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type testResumeTokenStore struct {
+	tokens map[string]bson.Raw
+}
+
+func (s *testResumeTokenStore) GetResumeToken(_ context.Context, streamName string) (bson.Raw, error) {
+	return s.tokens[streamName], nil
+}
+
+func (s *testResumeTokenStore) SaveResumeToken(_ context.Context, streamName string, resumeToken bson.Raw) error {
+	s.tokens[streamName] = resumeToken
+	return nil
+}
+
+type ChangeStreamSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func TestChangeStreamSuite(t *testing.T) {
+	s := new(ChangeStreamSuite)
+	suite.Run(t, s)
+}
+
+func (s *ChangeStreamSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *ChangeStreamSuite) TestWatch() {
+	entityMongoModel := newTestEntityModel(s.T())
+	store := &testResumeTokenStore{tokens: map[string]bson.Raw{}}
+	watchOpts := mgod.NewWatchOptions().SetResumeTokenStore(store, "test-stream")
+
+	stream, err := entityMongoModel.Watch(context.Background(), mongo.Pipeline{}, watchOpts)
+	s.NoError(err)
+
+	entity := insertTestEntity(s.T(), "watch")
+
+	s.True(stream.Next(context.Background()))
+
+	event, err := stream.Decode()
+	s.NoError(err)
+	s.Equal("insert", event.OperationType)
+	s.NotNil(event.FullDocument)
+	s.Equal(entity.ID, event.FullDocument.ID)
+
+	s.NoError(stream.SaveResumeToken(context.Background()))
+	s.Equal(event.ResumeToken, store.tokens["test-stream"])
+	s.NoError(stream.Close(context.Background()))
+
+	// resumed stream should start after the saved event.
+	resumedStream, err := entityMongoModel.Watch(context.Background(), mongo.Pipeline{}, watchOpts)
+	s.NoError(err)
+
+	_, err = entityMongoModel.DeleteOne(context.Background(), bson.M{"_id": entity.ID})
+	s.NoError(err)
+
+	s.True(resumedStream.Next(context.Background()))
+
+	event, err = resumedStream.Decode()
+	s.NoError(err)
+	s.Equal("delete", event.OperationType)
+	s.Nil(event.FullDocument)
+	s.NoError(resumedStream.Close(context.Background()))
+}
+
+func (s *ChangeStreamSuite) TestWatchWithAfterFindHooks() {
+	var hookedDocs []bson.D
+	hooks := mgod.ModelHooks{
+		AfterFind: func(_ context.Context, doc bson.D) error {
+			hookedDocs = append(hookedDocs, doc)
+			return nil
+		},
+	}
+
+	entityMongoModel := newTestModel(s.T(), testHookedEntity{}, "changeStreamHooks", nil, hooks)
+
+	stream, err := entityMongoModel.Watch(context.Background(), mongo.Pipeline{})
+	s.NoError(err)
+
+	entity, err := entityMongoModel.InsertOne(context.Background(), testHookedEntity{
+		ID:   primitive.NewObjectID().Hex(),
+		Name: "watch-hooks",
+	})
+	s.NoError(err)
+
+	s.True(stream.Next(context.Background()))
+
+	event, err := stream.Decode()
+	s.NoError(err)
+	s.Equal(entity.ID, event.FullDocument.ID)
+	s.True(event.FullDocument.Loaded)
+	s.Len(hookedDocs, 1)
+	s.NoError(stream.Close(context.Background()))
+}
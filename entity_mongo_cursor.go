@@ -0,0 +1,98 @@
+package mgod
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// EntityMongoCursor is a typed iterator over the documents returned by a query.
+// Documents are fetched from MongoDB one batch at a time (see [options.FindOptions.BatchSize]) and are
+// translated to T (e.g. the entity model) only when decoded, so the complete result set is never held in memory.
+type EntityMongoCursor[T any] interface {
+	// Next gets the next document for the cursor. It returns true if there were no errors and the cursor
+	// has not been exhausted.
+	Next(ctx context.Context) bool
+
+	// Decode translates the current document of the cursor to T.
+	Decode() (T, error)
+
+	// ForEach calls the provided callback for every remaining document of the cursor and closes the cursor afterwards.
+	// Iteration stops at the first error returned either by the callback or while decoding a document.
+	ForEach(ctx context.Context, callback func(model T) error) error
+
+	// Err returns the last error seen by the cursor, or nil if no error has occurred.
+	Err() error
+
+	// Close closes the cursor. Next and Decode must not be called after the cursor has been closed.
+	Close(ctx context.Context) error
+}
+
+// cursorDecoder translates a document fetched by a cursor to T.
+type cursorDecoder[T any] func(ctx context.Context, doc bson.D) (T, error)
+
+type entityMongoCursor[T any] struct {
+	cursor *mongo.Cursor
+	decode cursorDecoder[T]
+
+	// ctx is the context provided in the last call to Next. It is used to translate the document in Decode.
+	ctx context.Context
+}
+
+func newEntityMongoCursor[T any](ctx context.Context, cursor *mongo.Cursor, decode cursorDecoder[T]) EntityMongoCursor[T] {
+	return &entityMongoCursor[T]{
+		cursor: cursor,
+		decode: decode,
+		ctx:    ctx,
+	}
+}
+
+func (c *entityMongoCursor[T]) Next(ctx context.Context) bool {
+	c.ctx = ctx
+
+	return c.cursor.Next(ctx)
+}
+
+func (c *entityMongoCursor[T]) Decode() (T, error) {
+	var doc bson.D
+	if err := c.cursor.Decode(&doc); err != nil {
+		var model T
+		return model, err
+	}
+
+	return c.decode(c.ctx, doc)
+}
+
+func (c *entityMongoCursor[T]) ForEach(ctx context.Context, callback func(model T) error) error {
+	iterErr := c.forEach(ctx, callback)
+
+	if closeErr := c.cursor.Close(ctx); iterErr == nil {
+		return closeErr
+	}
+
+	return iterErr
+}
+
+func (c *entityMongoCursor[T]) forEach(ctx context.Context, callback func(model T) error) error {
+	for c.Next(ctx) {
+		model, err := c.Decode()
+		if err != nil {
+			return err
+		}
+
+		if err = callback(model); err != nil {
+			return err
+		}
+	}
+
+	return c.cursor.Err()
+}
+
+func (c *entityMongoCursor[T]) Err() error {
+	return c.cursor.Err()
+}
+
+func (c *entityMongoCursor[T]) Close(ctx context.Context) error {
+	return c.cursor.Close(ctx)
+}
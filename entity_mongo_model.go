@@ -3,6 +3,7 @@ package mgod
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 
 	"github.com/Lyearn/mgod/errors"
@@ -16,6 +17,8 @@ import (
 )
 
 // EntityMongoModel is a generic interface of available wrapper functions on MongoDB collection.
+//
+//nolint:interfacebloat // mirrors the operations available on mongo.Collection
 type EntityMongoModel[T any] interface {
 	// GetDocToInsert returns the bson.D doc to be inserted in the collection for the provided struct object.
 	// This function is mainly used while creating a doc to be inserted for Union Type models because the underlying type of a union
@@ -30,28 +33,75 @@ type EntityMongoModel[T any] interface {
 	// Docs is kept as interface{} to support Union Type models i.e. accept both []bson.D (generated using GetDocToInsert()) and []struct objects.
 	InsertMany(ctx context.Context, docs interface{}, opts ...*options.InsertManyOptions) ([]T, error)
 
+	// UpdateOne updates a single filtered document in the collection based on the provided update query.
+	UpdateOne(ctx context.Context, filter, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+
+	// UpdateOneWithVersion updates a single filtered document in the same way as UpdateOne, only if the stored doc has
+	// the provided version (the version the doc was loaded with). [errors.ErrVersionConflict] is returned if the doc
+	// has been modified in the meantime. It requires the OptimisticConcurrency schema option to be enabled.
+	UpdateOneWithVersion(ctx context.Context, filter, update interface{}, expectedVersion int,
+		opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+
 	// UpdateMany updates multiple filtered documents in the collection based on the provided update query.
 	UpdateMany(ctx context.Context, filter, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
 
+	// ReplaceOne replaces a single filtered document in the collection with the provided struct object.
+	// The replacement doc is built in the same way as in InsertOne i.e. defaults and meta fields are added to it.
+	ReplaceOne(ctx context.Context, filter interface{}, model T, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error)
+
 	// BulkWrite performs multiple write operations on the collection at once.
 	// Currently, only InsertOne, UpdateOne, and UpdateMany operations are supported.
 	BulkWrite(ctx context.Context, bulkWrites []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error)
 
 	// Find returns all documents in the collection matching the provided filter.
+	// Reference fields provided in the Populate query option (see [WithQueryOptions]) are populated in the returned docs.
+	// Fields deselected using `mgoSelect:"false"` are excluded unless the Select query option or a projection is provided.
+	// Soft deleted docs are excluded unless the WithDeleted or OnlyDeleted query option is provided. The same applies to
+	// FindOne, FindOneAndUpdate, CountDocuments, Distinct and Aggregate.
 	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) ([]T, error)
 
+	// FindCursor returns a cursor over all documents in the collection matching the provided filter.
+	// Unlike Find, documents are fetched and translated to the entity model lazily while iterating the cursor.
+	FindCursor(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (EntityMongoCursor[T], error)
+
 	// FindOne returns a single document from the collection matching the provided filter.
+	// Reference fields are populated and deselected fields are excluded in the same way as in Find.
+	// Unlike the FindOneAndX operations which return [errors.ErrNotFound], it returns a nil doc without an error if
+	// no document matches the filter.
 	FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) (*T, error)
 
 	// FindOneAndUpdate returns a single document from the collection based on the provided filter and updates it.
+	// Deselected fields are excluded from the returned doc in the same way as in Find.
 	FindOneAndUpdate(ctx context.Context, filter, update interface{}, opts ...*options.FindOneAndUpdateOptions) (T, error)
 
+	// FindOneAndUpdateWithVersion returns and updates a single document in the same way as FindOneAndUpdate, only if
+	// the stored doc has the provided version. The version is checked in the same way as in UpdateOneWithVersion.
+	FindOneAndUpdateWithVersion(ctx context.Context, filter, update interface{}, expectedVersion int,
+		opts ...*options.FindOneAndUpdateOptions) (T, error)
+
+	// FindOneAndReplace returns a single document from the collection based on the provided filter and replaces it
+	// with the provided struct object. The replacement doc is built in the same way as in ReplaceOne.
+	FindOneAndReplace(ctx context.Context, filter interface{}, model T, opts ...*options.FindOneAndReplaceOptions) (T, error)
+
+	// FindOneAndDelete returns a single document from the collection based on the provided filter and deletes it.
+	// The doc is soft deleted if soft delete is enabled for the entity (see [schemaopt.SchemaOptions.SoftDelete]).
+	FindOneAndDelete(ctx context.Context, filter interface{}, opts ...*options.FindOneAndDeleteOptions) (T, error)
+
 	// DeleteOne deletes a single document in the collection based on the provided filter.
+	// The doc is soft deleted if soft delete is enabled for the entity.
 	DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
 
 	// DeleteMany deletes multiple documents in the collection based on the provided filter.
+	// The docs are soft deleted if soft delete is enabled for the entity.
 	DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
 
+	// Restore restores the soft deleted documents in the collection matching the provided filter.
+	// It returns an error if soft delete is not enabled for the entity.
+	Restore(ctx context.Context, filter interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+
+	// HardDelete removes the documents in the collection matching the provided filter, including the soft deleted ones.
+	HardDelete(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+
 	// CountDocuments returns the number of documents in the collection for the provided filter.
 	CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error)
 
@@ -59,40 +109,77 @@ type EntityMongoModel[T any] interface {
 	Distinct(ctx context.Context, fieldName string, filter interface{}, opts ...*options.DistinctOptions) ([]interface{}, error)
 
 	// Aggregate performs an aggregation operation on the collection and returns the results.
+	// Soft deleted docs are excluded by adding a condition to the first $match stage of the pipeline (or a new one).
 	Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) ([]bson.D, error)
+
+	// AggregateCursor performs an aggregation operation on the collection in the same way as Aggregate and returns
+	// a cursor over the results, which are fetched lazily while iterating the cursor.
+	AggregateCursor(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (EntityMongoCursor[bson.D], error)
+
+	// EnsureIndexes creates the indexes declared on the entity model (using mgoIndex tag) which are not present in
+	// the collection. An error is returned if an existing index has the same name as a declared index but different
+	// keys or options. Indexes not declared on the entity model are left as it is.
+	EnsureIndexes(ctx context.Context) error
+
+	// DiffIndexes compares the indexes declared on the entity model with the indexes present in the collection.
+	DiffIndexes(ctx context.Context) (IndexDiff, error)
+
+	// GetJSONSchema returns the MongoDB $jsonSchema document built from the entity model schema.
+	GetJSONSchema() bson.D
+
+	// ApplyJSONSchemaValidator applies the $jsonSchema document of the entity model as the validator of the collection
+	// using collMod. The collection is created with the validator if it doesn't exist yet.
+	// It is not supported for union type models.
+	ApplyJSONSchemaValidator(ctx context.Context, opts ...*JSONSchemaValidatorOptions) error
+
+	// Watch opens a change stream on the collection for the provided aggregation pipeline.
+	// Full documents of the change events are translated to the entity model. See [WatchOptions] to resume the change
+	// stream using a persisted resume token.
+	// With the Tenancy schema option, only the change events of the docs of the tenant in the context are returned.
+	Watch(ctx context.Context, pipeline interface{}, opts ...*WatchOptions) (EntityMongoChangeStream[T], error)
 }
 
 type entityMongoModel[T any] struct {
 	modelType  T
 	schemaOpts schemaopt.SchemaOptions
-	coll       *mongo.Collection
+	clientName string
+	dbName     string
+	collName   string
+	dbResolver DBResolver
 
 	schema *schema.EntityModelSchema
 
 	isUnionType      bool
 	discriminatorKey string
+
+	hooks []ModelHooks
 }
 
 // NewEntityMongoModel returns a new instance of EntityMongoModel for the provided model type and options.
 func NewEntityMongoModel[T any](modelType T, opts entityMongoModelOptions) (EntityMongoModel[T], error) {
-	dbConn := getDBConn(opts.connOpts.db)
-	if dbConn == nil {
-		return nil, errors.ErrNoDatabaseConnection
+	clientName := opts.connOpts.clientName
+	if clientName == "" {
+		clientName = DefaultClientName
 	}
 
-	coll := dbConn.Collection(opts.connOpts.coll)
-
-	modelName := schema.GetSchemaNameForModel(modelType)
-	schemaCacheKey := GetSchemaCacheKey(coll.Name(), modelName)
-
-	var entityModelSchema *schema.EntityModelSchema
-	var err error
+	if _, err := getClient(clientName); err != nil {
+		return nil, err
+	}
 
 	schemaOpts := schemaopt.SchemaOptions{}
 	if opts.schemaOpts != nil {
 		schemaOpts = *opts.schemaOpts
 	}
 
+	modelName := schema.GetSchemaNameForModel(modelType)
+	schemaCacheKey := GetSchemaCacheKey(opts.connOpts.coll, modelName)
+	if schemaOptsCacheKey := getSchemaOptionsCacheKey(schemaOpts); schemaOptsCacheKey != "" {
+		schemaCacheKey = GetSchemaCacheKey(schemaCacheKey, schemaOptsCacheKey)
+	}
+
+	var entityModelSchema *schema.EntityModelSchema
+	var err error
+
 	// build schema if not cached.
 	if entityModelSchema, err = schema.EntityModelSchemaCacheInstance.GetSchema(schemaCacheKey); err != nil {
 		entityModelSchema, err = schema.BuildSchemaForModel(modelType, schemaOpts)
@@ -103,6 +190,8 @@ func NewEntityMongoModel[T any](modelType T, opts entityMongoModelOptions) (Enti
 		schema.EntityModelSchemaCacheInstance.SetSchema(schemaCacheKey, entityModelSchema)
 	}
 
+	trackClientSchema(clientName, schemaCacheKey)
+
 	isUnionTypeModel := schemaOpts.IsUnionType
 
 	discriminatorKey := "__t"
@@ -113,15 +202,21 @@ func NewEntityMongoModel[T any](modelType T, opts entityMongoModelOptions) (Enti
 	return &entityMongoModel[T]{
 		modelType:        modelType,
 		schemaOpts:       schemaOpts,
-		coll:             coll,
+		clientName:       clientName,
+		dbName:           opts.connOpts.db,
+		collName:         opts.connOpts.coll,
+		dbResolver:       opts.connOpts.dbResolver,
 		schema:           entityModelSchema,
 		isUnionType:      isUnionTypeModel,
 		discriminatorKey: discriminatorKey,
+		hooks:            opts.hooks,
 	}, nil
 }
 
-func (m entityMongoModel[T]) GetDocToInsert(ctx context.Context, doc T) (bson.D, error) {
-	bsonDoc, err := m.getMongoDocFromEntityModel(ctx, doc)
+func (m entityMongoModel[T]) GetDocToInsert(ctx context.Context, doc T) (_ bson.D, err error) {
+	defer wrapOperationError("GetDocToInsert", &err)
+
+	bsonDoc, err := m.getDocToInsertFromEntityModel(ctx, doc)
 	if err != nil {
 		return nil, err
 	}
@@ -131,29 +226,46 @@ func (m entityMongoModel[T]) GetDocToInsert(ctx context.Context, doc T) (bson.D,
 
 func (m entityMongoModel[T]) InsertOne(ctx context.Context, doc interface{},
 	opts ...*options.InsertOneOptions,
-) (T, error) {
+) (_ T, err error) {
+	defer wrapOperationError("InsertOne", &err)
+
 	model := m.getEntityModel()
 
 	var bsonDoc primitive.D
-	var err error
 
 	switch typedDoc := doc.(type) {
 	case bson.D:
-		bsonDoc = typedDoc
+		bsonDoc, err = m.addTenantToDoc(ctx, typedDoc)
+		if err != nil {
+			return model, err
+		}
 	case T:
-		bsonDoc, err = m.getMongoDocFromEntityModel(ctx, typedDoc)
+		bsonDoc, err = m.getDocToInsertFromEntityModel(ctx, typedDoc)
 		if err != nil {
 			return model, err
 		}
 	}
 
+	if err = runHooks(ctx, m.hooks, beforeInsertHook, &bsonDoc); err != nil {
+		return model, err
+	}
+
 	// TODO: add an extra strict check to ensure that the doc to be inserted contains _id field
 
-	_, err = m.coll.InsertOne(ctx, bsonDoc, opts...)
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return model, err
+	}
+
+	_, err = coll.InsertOne(ctx, bsonDoc, opts...)
 	if err != nil {
 		return model, err
 	}
 
+	if err = runHooks(ctx, m.hooks, afterInsertHook, bsonDoc); err != nil {
+		return model, err
+	}
+
 	model, err = m.getEntityModelFromMongoDoc(ctx, bsonDoc)
 
 	return model, err
@@ -161,13 +273,15 @@ func (m entityMongoModel[T]) InsertOne(ctx context.Context, doc interface{},
 
 func (m entityMongoModel[T]) InsertMany(ctx context.Context, docs interface{},
 	opts ...*options.InsertManyOptions,
-) ([]T, error) {
+) (_ []T, err error) {
+	defer wrapOperationError("InsertMany", &err)
+
 	bsonDocs := []interface{}{}
 
 	switch typedDocs := docs.(type) {
 	case []T:
 		for _, doc := range typedDocs {
-			bsonDoc, err := m.getMongoDocFromEntityModel(ctx, doc)
+			bsonDoc, err := m.getDocToInsertFromEntityModel(ctx, doc)
 			if err != nil {
 				return nil, err
 			}
@@ -175,9 +289,14 @@ func (m entityMongoModel[T]) InsertMany(ctx context.Context, docs interface{},
 			bsonDocs = append(bsonDocs, bsonDoc)
 		}
 	case []bson.D:
-		bsonDocs = lo.Map(typedDocs, func(typedDoc bson.D, _ int) interface{} {
-			return typedDoc
-		})
+		for _, doc := range typedDocs {
+			bsonDoc, err := m.addTenantToDoc(ctx, doc)
+			if err != nil {
+				return nil, err
+			}
+
+			bsonDocs = append(bsonDocs, bsonDoc)
+		}
 	default:
 		var dummyTypedVar T
 		return nil, errors.NewBadRequestError(errors.BadRequestError{
@@ -187,11 +306,31 @@ func (m entityMongoModel[T]) InsertMany(ctx context.Context, docs interface{},
 		})
 	}
 
-	_, err := m.coll.InsertMany(ctx, bsonDocs, opts...)
+	for idx := range bsonDocs {
+		bsonDoc := bsonDocs[idx].(bson.D)
+		if err := runHooks(ctx, m.hooks, beforeInsertHook, &bsonDoc); err != nil {
+			return nil, err
+		}
+
+		bsonDocs[idx] = bsonDoc
+	}
+
+	coll, err := m.getCollection(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	_, err = coll.InsertMany(ctx, bsonDocs, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bsonDoc := range bsonDocs {
+		if err = runHooks(ctx, m.hooks, afterInsertHook, bsonDoc.(bson.D)); err != nil {
+			return nil, err
+		}
+	}
+
 	models := []T{}
 
 	// TODO: transform and return only those docs which are inserted successfully (use result from InsertMany)
@@ -207,30 +346,166 @@ func (m entityMongoModel[T]) InsertMany(ctx context.Context, docs interface{},
 	return models, err
 }
 
-func (m entityMongoModel[T]) UpdateMany(ctx context.Context, filter, update interface{},
+func (m entityMongoModel[T]) UpdateOne(ctx context.Context, filter, update interface{},
+	opts ...*options.UpdateOptions,
+) (_ *mongo.UpdateResult, err error) {
+	defer wrapOperationError("UpdateOne", &err)
+
+	return m.updateOne(ctx, "UpdateOne", filter, update, nil, opts...)
+}
+
+func (m entityMongoModel[T]) UpdateOneWithVersion(ctx context.Context, filter, update interface{}, expectedVersion int,
 	opts ...*options.UpdateOptions,
+) (_ *mongo.UpdateResult, err error) {
+	defer wrapOperationError("UpdateOneWithVersion", &err)
+
+	return m.updateOne(ctx, "UpdateOneWithVersion", filter, update, &expectedVersion, opts...)
+}
+
+// updateOne updates a single filtered document. The expected version is added to the filter if provided.
+func (m entityMongoModel[T]) updateOne(ctx context.Context, funcName string, filter, update interface{},
+	expectedVersion *int, opts ...*options.UpdateOptions,
 ) (*mongo.UpdateResult, error) {
-	updateQuery, err := m.handleTimestampsForUpdateQuery(update, "UpdateMany")
+	filterQuery, err := m.buildTenantFilter(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := m.coll.UpdateMany(ctx, filter, updateQuery, opts...)
+	updateQuery, err := m.buildUpdateQuery(ctx, update, funcName)
+	if err != nil {
+		return nil, err
+	}
+
+	versionedFilterQuery, isVersionAdded, err := m.addExpectedVersionToFilter(filterQuery, expectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = runHooks(ctx, m.hooks, beforeUpdateHook(versionedFilterQuery), &updateQuery); err != nil {
+		return nil, err
+	}
+
+	coll, err := m.getCollection(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	result, err := coll.UpdateOne(ctx, versionedFilterQuery, updateQuery, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.MatchedCount == 0 && result.UpsertedCount == 0 {
+		if err = m.checkDocVersionConflict(ctx, filterQuery, isVersionAdded); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = runHooks(ctx, m.hooks, afterUpdateHook(versionedFilterQuery), updateQuery); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (m entityMongoModel[T]) UpdateMany(ctx context.Context, filter, update interface{},
+	opts ...*options.UpdateOptions,
+) (_ *mongo.UpdateResult, err error) {
+	defer wrapOperationError("UpdateMany", &err)
+
+	filterQuery, err := m.buildTenantFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	updateQuery, err := m.buildUpdateQuery(ctx, update, "UpdateMany")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = runHooks(ctx, m.hooks, beforeUpdateHook(filterQuery), &updateQuery); err != nil {
+		return nil, err
+	}
+
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := coll.UpdateMany(ctx, filterQuery, updateQuery, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = runHooks(ctx, m.hooks, afterUpdateHook(filterQuery), updateQuery); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (m entityMongoModel[T]) ReplaceOne(ctx context.Context, filter interface{}, model T,
+	opts ...*options.ReplaceOptions,
+) (_ *mongo.UpdateResult, err error) {
+	defer wrapOperationError("ReplaceOne", &err)
+
+	filterQuery, err := m.buildTenantFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	replacement, docVersion, err := m.getReplacementDocFromEntityModel(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	versionedFilterQuery, err := m.addDocVersionToReplaceFilter(filterQuery, docVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = runHooks(ctx, m.hooks, beforeReplaceHook(versionedFilterQuery), &replacement); err != nil {
+		return nil, err
+	}
+
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := coll.ReplaceOne(ctx, versionedFilterQuery, replacement, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.MatchedCount == 0 && result.UpsertedCount == 0 {
+		if err = m.checkDocVersionConflict(ctx, filterQuery, true); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = runHooks(ctx, m.hooks, afterReplaceHook(versionedFilterQuery), replacement); err != nil {
+		return result, err
+	}
+
 	return result, nil
 }
 
 func (m entityMongoModel[T]) BulkWrite(ctx context.Context, bulkWrites []mongo.WriteModel,
 	opts ...*options.BulkWriteOptions,
-) (*mongo.BulkWriteResult, error) {
-	err := m.transformToBulkWriteBSONDocs(ctx, bulkWrites)
+) (_ *mongo.BulkWriteResult, err error) {
+	defer wrapOperationError("BulkWrite", &err)
+
+	err = m.transformToBulkWriteBSONDocs(ctx, bulkWrites)
+	if err != nil {
+		return nil, err
+	}
+	coll, err := m.getCollection(ctx)
 	if err != nil {
 		return nil, err
 	}
-	result, err := m.coll.BulkWrite(ctx, bulkWrites, opts...)
+
+	result, err := coll.BulkWrite(ctx, bulkWrites, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -239,111 +514,388 @@ func (m entityMongoModel[T]) BulkWrite(ctx context.Context, bulkWrites []mongo.W
 
 func (m entityMongoModel[T]) Find(ctx context.Context, filter interface{},
 	opts ...*options.FindOptions,
-) ([]T, error) {
-	cursor, err := m.coll.Find(ctx, filter, opts...)
+) (_ []T, err error) {
+	defer wrapOperationError("Find", &err)
+
+	return m.find(ctx, filter, opts...)
+}
+
+// find is the implementation of Find which doesn't wrap the returned error, so that it can be used by
+// the other operations.
+func (m entityMongoModel[T]) find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) ([]T, error) {
+	cursor, err := m.findCursor(ctx, filter, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	var docs []bson.D
-	if err = cursor.All(ctx, &docs); err != nil {
+	var models []T
+	err = cursor.ForEach(ctx, func(model T) error {
+		models = append(models, model)
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	var models []T
-	for _, doc := range docs {
-		model, err := m.getEntityModelFromMongoDoc(ctx, doc)
-		if err != nil {
-			return nil, err
+	if err = m.populate(ctx, models); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (m entityMongoModel[T]) FindCursor(ctx context.Context, filter interface{},
+	opts ...*options.FindOptions,
+) (_ EntityMongoCursor[T], err error) {
+	defer wrapOperationError("FindCursor", &err)
+
+	return m.findCursor(ctx, filter, opts...)
+}
+
+// findCursor is the implementation of FindCursor which doesn't wrap the returned error, so that it can be used by
+// the other operations.
+func (m entityMongoModel[T]) findCursor(ctx context.Context, filter interface{},
+	opts ...*options.FindOptions,
+) (EntityMongoCursor[T], error) {
+	filterQuery, err := m.buildScopedFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = runHooks(ctx, m.hooks, beforeFindHook, filterQuery); err != nil {
+		return nil, err
+	}
+
+	if !lo.SomeBy(opts, func(opt *options.FindOptions) bool { return opt != nil && opt.Projection != nil }) {
+		if projection := m.getDefaultProjection(ctx); projection != nil {
+			opts = append(opts[:len(opts):len(opts)], options.Find().SetProjection(projection))
 		}
+	}
 
-		models = append(models, model)
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return models, nil
+	cursor, err := coll.Find(ctx, filterQuery, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newEntityMongoCursor(ctx, cursor, m.getEntityModelFromFoundDoc), nil
 }
 
 func (m entityMongoModel[T]) FindOne(ctx context.Context, filter interface{},
 	opts ...*options.FindOneOptions,
-) (*T, error) {
-	cursor := m.coll.FindOne(ctx, filter, opts...)
+) (_ *T, err error) {
+	defer wrapOperationError("FindOne", &err)
+
+	filterQuery, err := m.buildScopedFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = runHooks(ctx, m.hooks, beforeFindHook, filterQuery); err != nil {
+		return nil, err
+	}
+
+	if !lo.SomeBy(opts, func(opt *options.FindOneOptions) bool { return opt != nil && opt.Projection != nil }) {
+		if projection := m.getDefaultProjection(ctx); projection != nil {
+			opts = append(opts[:len(opts):len(opts)], options.FindOne().SetProjection(projection))
+		}
+	}
+
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := coll.FindOne(ctx, filterQuery, opts...)
 
 	var doc bson.D
 
 	model := m.getEntityModel()
-	var err error
 
 	if err = cursor.Decode(&doc); err != nil {
-		if err.Error() == mongo.ErrNoDocuments.Error() {
+		if goerrors.Is(err, mongo.ErrNoDocuments) {
 			//nolint:nilnil // this is the expected behavior
 			return nil, nil
 		}
 		return nil, err
 	}
 
-	model, err = m.getEntityModelFromMongoDoc(ctx, doc)
+	model, err = m.getEntityModelFromFoundDoc(ctx, doc)
 	if err != nil {
 		return nil, err
 	}
 
-	return &model, nil
+	models := []T{model}
+	if err = m.populate(ctx, models); err != nil {
+		return nil, err
+	}
+
+	return &models[0], nil
 }
 
 func (m entityMongoModel[T]) FindOneAndUpdate(ctx context.Context, filter, update interface{},
 	opts ...*options.FindOneAndUpdateOptions,
+) (_ T, err error) {
+	defer wrapOperationError("FindOneAndUpdate", &err)
+
+	return m.findOneAndUpdate(ctx, "FindOneAndUpdate", filter, update, nil, opts...)
+}
+
+func (m entityMongoModel[T]) FindOneAndUpdateWithVersion(ctx context.Context, filter, update interface{},
+	expectedVersion int, opts ...*options.FindOneAndUpdateOptions,
+) (_ T, err error) {
+	defer wrapOperationError("FindOneAndUpdateWithVersion", &err)
+
+	return m.findOneAndUpdate(ctx, "FindOneAndUpdateWithVersion", filter, update, &expectedVersion, opts...)
+}
+
+// findOneAndUpdate returns and updates a single filtered document. The expected version is added to the filter
+// if provided.
+func (m entityMongoModel[T]) findOneAndUpdate(ctx context.Context, funcName string, filter, update interface{},
+	expectedVersion *int, opts ...*options.FindOneAndUpdateOptions,
 ) (T, error) {
 	model := m.getEntityModel()
-	var err error
 
-	updateQuery, err := m.handleTimestampsForUpdateQuery(update, "FindOneAndUpdate")
+	filterQuery, err := m.buildScopedFilter(ctx, filter)
 	if err != nil {
 		return model, err
 	}
 
-	cursor := m.coll.FindOneAndUpdate(ctx, filter, updateQuery, opts...)
+	updateQuery, err := m.buildUpdateQuery(ctx, update, funcName)
+	if err != nil {
+		return model, err
+	}
 
-	var doc bson.D
+	versionedFilterQuery, isVersionAdded, err := m.addExpectedVersionToFilter(filterQuery, expectedVersion)
+	if err != nil {
+		return model, err
+	}
 
-	if err = cursor.Decode(&doc); err != nil {
+	if err = runHooks(ctx, m.hooks, beforeUpdateHook(versionedFilterQuery), &updateQuery); err != nil {
 		return model, err
 	}
 
-	model, err = m.getEntityModelFromMongoDoc(ctx, doc)
+	if !lo.SomeBy(opts, func(opt *options.FindOneAndUpdateOptions) bool { return opt != nil && opt.Projection != nil }) {
+		if projection := m.getDefaultProjection(ctx); projection != nil {
+			opts = append(opts[:len(opts):len(opts)], options.FindOneAndUpdate().SetProjection(projection))
+		}
+	}
+
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return model, err
+	}
+
+	cursor := coll.FindOneAndUpdate(ctx, versionedFilterQuery, updateQuery, opts...)
+
+	model, err = m.decodeSingleResult(ctx, cursor)
+	if err != nil {
+		return model, m.handleDocVersionConflictForSingleResult(ctx, err, filterQuery, isVersionAdded)
+	}
+
+	if err = runHooks(ctx, m.hooks, afterUpdateHook(versionedFilterQuery), updateQuery); err != nil {
+		return model, err
+	}
+
+	return model, nil
+}
+
+func (m entityMongoModel[T]) FindOneAndReplace(ctx context.Context, filter interface{}, model T,
+	opts ...*options.FindOneAndReplaceOptions,
+) (_ T, err error) {
+	defer wrapOperationError("FindOneAndReplace", &err)
+
+	filterQuery, err := m.buildTenantFilter(ctx, filter)
+	if err != nil {
+		return m.getEntityModel(), err
+	}
+
+	replacement, docVersion, err := m.getReplacementDocFromEntityModel(ctx, model)
+	if err != nil {
+		return m.getEntityModel(), err
+	}
+
+	versionedFilterQuery, err := m.addDocVersionToReplaceFilter(filterQuery, docVersion)
+	if err != nil {
+		return m.getEntityModel(), err
+	}
+
+	if err = runHooks(ctx, m.hooks, beforeReplaceHook(versionedFilterQuery), &replacement); err != nil {
+		return m.getEntityModel(), err
+	}
+
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return m.getEntityModel(), err
+	}
+
+	cursor := coll.FindOneAndReplace(ctx, versionedFilterQuery, replacement, opts...)
+
+	result, err := m.decodeSingleResult(ctx, cursor)
+	if err != nil {
+		return result, m.handleDocVersionConflictForSingleResult(ctx, err, filterQuery, true)
+	}
+
+	if err = runHooks(ctx, m.hooks, afterReplaceHook(versionedFilterQuery), replacement); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (m entityMongoModel[T]) FindOneAndDelete(ctx context.Context, filter interface{},
+	opts ...*options.FindOneAndDeleteOptions,
+) (_ T, err error) {
+	defer wrapOperationError("FindOneAndDelete", &err)
+
+	if m.schemaOpts.SoftDelete {
+		return m.softFindOneAndDelete(ctx, filter, opts...)
+	}
+
+	filterQuery, err := m.buildTenantFilter(ctx, filter)
+	if err != nil {
+		return m.getEntityModel(), err
+	}
+
+	if err = runHooks(ctx, m.hooks, beforeDeleteHook, filterQuery); err != nil {
+		return m.getEntityModel(), err
+	}
+
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return m.getEntityModel(), err
+	}
+
+	cursor := coll.FindOneAndDelete(ctx, filterQuery, opts...)
+
+	model, err := m.decodeSingleResult(ctx, cursor)
 	if err != nil {
 		return model, err
 	}
 
+	if err = runHooks(ctx, m.hooks, afterDeleteHook, filterQuery); err != nil {
+		return model, err
+	}
+
 	return model, nil
 }
 
 func (m entityMongoModel[T]) DeleteOne(ctx context.Context, filter interface{},
 	opts ...*options.DeleteOptions,
-) (*mongo.DeleteResult, error) {
-	return m.coll.DeleteOne(ctx, filter, opts...)
+) (_ *mongo.DeleteResult, err error) {
+	defer wrapOperationError("DeleteOne", &err)
+
+	if m.schemaOpts.SoftDelete {
+		return m.softDelete(ctx, filter, false, "DeleteOne", opts...)
+	}
+
+	filterQuery, err := m.buildTenantFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = runHooks(ctx, m.hooks, beforeDeleteHook, filterQuery); err != nil {
+		return nil, err
+	}
+
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := coll.DeleteOne(ctx, filterQuery, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = runHooks(ctx, m.hooks, afterDeleteHook, filterQuery); err != nil {
+		return result, err
+	}
+
+	return result, nil
 }
 
 func (m entityMongoModel[T]) DeleteMany(ctx context.Context, filter interface{},
 	opts ...*options.DeleteOptions,
-) (*mongo.DeleteResult, error) {
-	return m.coll.DeleteMany(ctx, filter, opts...)
+) (_ *mongo.DeleteResult, err error) {
+	defer wrapOperationError("DeleteMany", &err)
+
+	if m.schemaOpts.SoftDelete {
+		return m.softDelete(ctx, filter, true, "DeleteMany", opts...)
+	}
+
+	filterQuery, err := m.buildTenantFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = runHooks(ctx, m.hooks, beforeDeleteHook, filterQuery); err != nil {
+		return nil, err
+	}
+
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := coll.DeleteMany(ctx, filterQuery, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = runHooks(ctx, m.hooks, afterDeleteHook, filterQuery); err != nil {
+		return result, err
+	}
+
+	return result, nil
 }
 
 func (m entityMongoModel[T]) CountDocuments(ctx context.Context, filter interface{},
 	opts ...*options.CountOptions,
-) (int64, error) {
-	return m.coll.CountDocuments(ctx, filter, opts...)
+) (_ int64, err error) {
+	defer wrapOperationError("CountDocuments", &err)
+
+	filterQuery, err := m.buildScopedFilter(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return coll.CountDocuments(ctx, filterQuery, opts...)
 }
 
 func (m entityMongoModel[T]) Distinct(ctx context.Context, fieldName string, filter interface{},
 	opts ...*options.DistinctOptions,
-) ([]interface{}, error) {
-	return m.coll.Distinct(ctx, fieldName, filter, opts...)
+) (_ []interface{}, err error) {
+	defer wrapOperationError("Distinct", &err)
+
+	filterQuery, err := m.buildScopedFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return coll.Distinct(ctx, fieldName, filterQuery, opts...)
 }
 
 func (m entityMongoModel[T]) Aggregate(ctx context.Context, pipeline interface{},
 	opts ...*options.AggregateOptions,
-) ([]bson.D, error) {
-	cursor, err := m.coll.Aggregate(ctx, pipeline, opts...)
+) (_ []bson.D, err error) {
+	defer wrapOperationError("Aggregate", &err)
+
+	cursor, err := m.aggregate(ctx, pipeline, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -355,3 +907,94 @@ func (m entityMongoModel[T]) Aggregate(ctx context.Context, pipeline interface{}
 
 	return docs, nil
 }
+
+func (m entityMongoModel[T]) AggregateCursor(ctx context.Context, pipeline interface{},
+	opts ...*options.AggregateOptions,
+) (_ EntityMongoCursor[bson.D], err error) {
+	defer wrapOperationError("AggregateCursor", &err)
+
+	cursor, err := m.aggregate(ctx, pipeline, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newEntityMongoCursor(ctx, cursor, func(_ context.Context, doc bson.D) (bson.D, error) {
+		return doc, nil
+	}), nil
+}
+
+// aggregate executes the provided aggregation pipeline scoped to the docs in the scope of the provided context.
+func (m entityMongoModel[T]) aggregate(ctx context.Context, pipeline interface{},
+	opts ...*options.AggregateOptions,
+) (*mongo.Cursor, error) {
+	scopedPipeline, err := m.scopePipeline(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return coll.Aggregate(ctx, scopedPipeline, opts...)
+}
+
+func (m entityMongoModel[T]) Watch(ctx context.Context, pipeline interface{},
+	opts ...*WatchOptions,
+) (_ EntityMongoChangeStream[T], err error) {
+	defer wrapOperationError("Watch", &err)
+
+	watchOpts := NewWatchOptions()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		if opt.changeStreamOpts != nil {
+			watchOpts.changeStreamOpts = opt.changeStreamOpts
+		}
+
+		if opt.resumeTokenStore != nil {
+			watchOpts.resumeTokenStore = opt.resumeTokenStore
+			watchOpts.streamName = opt.streamName
+		}
+	}
+
+	changeStreamOpts, err := watchOpts.getChangeStreamOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if pipeline == nil {
+		pipeline = mongo.Pipeline{}
+	}
+
+	scopedPipeline, err := m.scopeChangeStreamPipeline(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.schemaOpts.Tenancy != nil && changeStreamOpts.FullDocument == nil {
+		// update events hold the tenant only if the full doc is looked up.
+		changeStreamOpts.SetFullDocument(options.UpdateLookup)
+	}
+
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := coll.Watch(ctx, scopedPipeline, changeStreamOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entityMongoChangeStream[T]{
+		model:            m,
+		stream:           stream,
+		resumeTokenStore: watchOpts.resumeTokenStore,
+		streamName:       watchOpts.streamName,
+		ctx:              ctx,
+	}, nil
+}
@@ -0,0 +1,177 @@
+package mgod
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ModelHooks are the functions invoked before and after the operations of an EntityMongoModel.
+// All the hooks are optional and receive MongoDB representation of the docs and queries i.e. after they have been
+// built according to the entity model schema. An error returned from a before hook aborts the operation, whereas an
+// error returned from an after hook is returned by the operation after it has been executed.
+//
+// Hooks are not invoked for Aggregate operations and for any write model of a BulkWrite operation i.e. its insert,
+// update, replace and delete models don't run the BeforeX and AfterX hooks. Only the entity model hooks (see [Validator]
+// and [BeforeSaver]) are invoked for the entity models inserted or used as a replacement by a BulkWrite operation.
+type ModelHooks struct {
+	// BeforeInsert is invoked for every doc before it is inserted. The doc can be modified by the hook.
+	BeforeInsert func(ctx context.Context, doc *bson.D) error
+	// AfterInsert is invoked for every doc after it has been inserted.
+	AfterInsert func(ctx context.Context, doc bson.D) error
+
+	// BeforeUpdate is invoked before an update operation. The update query can be modified by the hook.
+	BeforeUpdate func(ctx context.Context, filter interface{}, update *bson.D) error
+	// AfterUpdate is invoked after an update operation has been executed.
+	AfterUpdate func(ctx context.Context, filter interface{}, update bson.D) error
+
+	// BeforeReplace is invoked before a replace operation. The replacement doc can be modified by the hook.
+	BeforeReplace func(ctx context.Context, filter interface{}, replacement *bson.D) error
+	// AfterReplace is invoked after a replace operation has been executed.
+	AfterReplace func(ctx context.Context, filter interface{}, replacement bson.D) error
+
+	// BeforeDelete is invoked before a delete operation.
+	BeforeDelete func(ctx context.Context, filter interface{}) error
+	// AfterDelete is invoked after a delete operation has been executed.
+	AfterDelete func(ctx context.Context, filter interface{}) error
+
+	// BeforeFind is invoked before a find operation.
+	BeforeFind func(ctx context.Context, filter interface{}) error
+	// AfterFind is invoked for every doc returned by a find operation (including FindOneAndX operations and the full
+	// documents of change events), before it is converted to the entity model.
+	AfterFind func(ctx context.Context, doc bson.D) error
+}
+
+// Validator can be implemented by an entity model to validate itself before it is inserted or used as a replacement.
+type Validator interface {
+	Validate() error
+}
+
+// BeforeSaver can be implemented by an entity model to modify itself before it is inserted or used as a replacement.
+// It is invoked after Validate.
+type BeforeSaver interface {
+	BeforeSave(ctx context.Context) error
+}
+
+// AfterFinder can be implemented by an entity model to modify itself after it has been fetched from the collection.
+type AfterFinder interface {
+	AfterFind(ctx context.Context) error
+}
+
+// getModelHook returns the hook of type H implemented either by the provided model or its pointer.
+func getModelHook[H any, T any](model *T) (H, bool) {
+	if hook, ok := any(model).(H); ok {
+		return hook, true
+	}
+
+	hook, ok := any(*model).(H)
+
+	return hook, ok
+}
+
+// runBeforeSaveHooks runs the Validate and BeforeSave hooks implemented by the provided entity model.
+func runBeforeSaveHooks[T any](ctx context.Context, model *T) error {
+	if validator, ok := getModelHook[Validator](model); ok {
+		if err := validator.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if beforeSaver, ok := getModelHook[BeforeSaver](model); ok {
+		if err := beforeSaver.BeforeSave(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runAfterFindHook runs the AfterFind hook implemented by the provided entity model.
+func runAfterFindHook[T any](ctx context.Context, model *T) error {
+	if afterFinder, ok := getModelHook[AfterFinder](model); ok {
+		return afterFinder.AfterFind(ctx)
+	}
+
+	return nil
+}
+
+// hookSelector returns the hook of an operation from the provided ModelHooks, or nil if it is not provided.
+type hookSelector[A any] func(hooks ModelHooks) func(ctx context.Context, arg A) error
+
+// runHooks runs the selected hook of the provided ModelHooks in the order of registration. Execution stops at the
+// first error.
+func runHooks[A any](ctx context.Context, hooks []ModelHooks, selectHook hookSelector[A], arg A) error {
+	for _, modelHooks := range hooks {
+		hook := selectHook(modelHooks)
+		if hook == nil {
+			continue
+		}
+
+		if err := hook(ctx, arg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Selectors of the hooks of the operations. Update and replace hooks are bound to the filter of the operation.
+
+func beforeInsertHook(hooks ModelHooks) func(context.Context, *bson.D) error {
+	return hooks.BeforeInsert
+}
+
+func afterInsertHook(hooks ModelHooks) func(context.Context, bson.D) error {
+	return hooks.AfterInsert
+}
+
+func beforeDeleteHook(hooks ModelHooks) func(context.Context, interface{}) error {
+	return hooks.BeforeDelete
+}
+
+func afterDeleteHook(hooks ModelHooks) func(context.Context, interface{}) error {
+	return hooks.AfterDelete
+}
+
+func beforeFindHook(hooks ModelHooks) func(context.Context, interface{}) error {
+	return hooks.BeforeFind
+}
+
+func afterFindHook(hooks ModelHooks) func(context.Context, bson.D) error {
+	return hooks.AfterFind
+}
+
+func beforeUpdateHook(filter interface{}) hookSelector[*bson.D] {
+	return func(hooks ModelHooks) func(context.Context, *bson.D) error {
+		return withFilter(hooks.BeforeUpdate, filter)
+	}
+}
+
+func afterUpdateHook(filter interface{}) hookSelector[bson.D] {
+	return func(hooks ModelHooks) func(context.Context, bson.D) error {
+		return withFilter(hooks.AfterUpdate, filter)
+	}
+}
+
+func beforeReplaceHook(filter interface{}) hookSelector[*bson.D] {
+	return func(hooks ModelHooks) func(context.Context, *bson.D) error {
+		return withFilter(hooks.BeforeReplace, filter)
+	}
+}
+
+func afterReplaceHook(filter interface{}) hookSelector[bson.D] {
+	return func(hooks ModelHooks) func(context.Context, bson.D) error {
+		return withFilter(hooks.AfterReplace, filter)
+	}
+}
+
+// withFilter binds the filter of the operation to the provided hook. It returns nil if the hook is not provided.
+func withFilter[A any](hook func(context.Context, interface{}, A) error, filter interface{}) func(context.Context, A) error {
+	if hook == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, arg A) error {
+		return hook(ctx, filter, arg)
+	}
+}
@@ -0,0 +1,75 @@
+package mgod_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Lyearn/mgod"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type HooksSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func TestHooksSuite(t *testing.T) {
+	s := new(HooksSuite)
+	suite.Run(t, s)
+}
+
+func (s *HooksSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *HooksSuite) TestHooks() {
+	executedHooks := []string{}
+
+	hooks := mgod.ModelHooks{
+		BeforeUpdate: func(_ context.Context, _ interface{}, _ *bson.D) error {
+			executedHooks = append(executedHooks, "BeforeUpdate")
+			return nil
+		},
+		AfterUpdate: func(_ context.Context, _ interface{}, _ bson.D) error {
+			executedHooks = append(executedHooks, "AfterUpdate")
+			return nil
+		},
+		BeforeDelete: func(_ context.Context, _ interface{}) error {
+			return fmt.Errorf("delete not allowed")
+		},
+	}
+
+	entityMongoModel := newTestModel(s.T(), testHookedEntity{}, "entityMongoModelHooked", nil, hooks)
+
+	_, err := entityMongoModel.InsertOne(context.Background(), testHookedEntity{ID: primitive.NewObjectID().Hex()})
+	s.ErrorContains(err, "name is required")
+
+	entity, err := entityMongoModel.InsertOne(context.Background(), testHookedEntity{
+		ID:   primitive.NewObjectID().Hex(),
+		Name: "hooked",
+	})
+	s.NoError(err)
+	s.Equal("gopher", entity.CreatedBy)
+	s.False(entity.Loaded)
+
+	_, err = entityMongoModel.UpdateOne(context.Background(), bson.M{"_id": entity.ID}, bson.D{
+		{Key: "$set", Value: bson.D{{Key: "name", Value: "hooked-updated"}}},
+	})
+	s.NoError(err)
+	s.Equal([]string{"BeforeUpdate", "AfterUpdate"}, executedHooks)
+
+	foundEntity, err := entityMongoModel.FindOne(context.Background(), bson.M{"_id": entity.ID})
+	s.NoError(err)
+	s.True(foundEntity.Loaded)
+
+	_, err = entityMongoModel.DeleteOne(context.Background(), bson.M{"_id": entity.ID})
+	s.ErrorContains(err, "delete not allowed")
+
+	count, err := entityMongoModel.CountDocuments(context.Background(), bson.M{"_id": entity.ID})
+	s.NoError(err)
+	s.Equal(int64(1), count)
+}
@@ -1,19 +1,28 @@
 package mgod
 
 import (
+	"context"
+
 	"github.com/Lyearn/mgod/schema/schemaopt"
 )
 
 type entityMongoModelOptions struct {
 	connOpts   connectionOptions
 	schemaOpts *schemaopt.SchemaOptions
+	hooks      []ModelHooks
 }
 
 type connectionOptions struct {
-	db   string
-	coll string
+	clientName string
+	db         string
+	coll       string
+	dbResolver DBResolver
 }
 
+// DBResolver returns the name of the database to be used for an operation from the provided context.
+// Empty name falls back to the database provided in NewEntityMongoModelOptions.
+type DBResolver func(ctx context.Context) string
+
 // NewEntityMongoModelOptions creates a new entityMongoModelOptions instance.
 // Its instance is used to provide necessary configuration options to the NewEntityMongoModel function.
 //
@@ -29,3 +38,25 @@ func NewEntityMongoModelOptions(dbName string, collection string, schemaOpts *sc
 		schemaOpts: schemaOpts,
 	}
 }
+
+// AddHooks registers the provided hooks for the entity. Hooks are invoked in the order of registration.
+// See [ModelHooks] for more details.
+func (o *entityMongoModelOptions) AddHooks(hooks ...ModelHooks) *entityMongoModelOptions {
+	o.hooks = append(o.hooks, hooks...)
+	return o
+}
+
+// SetDBResolver sets the resolver choosing the database of the entity per operation (e.g. database-per-tenant).
+// Models using a resolver share the same schema, and the database connections are cached in a connection cache
+// whose idle entries can be evicted using [SetDBConnIdleTimeout].
+func (o *entityMongoModelOptions) SetDBResolver(resolver DBResolver) *entityMongoModelOptions {
+	o.connOpts.dbResolver = resolver
+	return o
+}
+
+// SetClientName sets the name of the MongoDB client (registered using [RegisterClient]) used by the entity.
+// Defaults to [DefaultClientName].
+func (o *entityMongoModelOptions) SetClientName(clientName string) *entityMongoModelOptions {
+	o.connOpts.clientName = clientName
+	return o
+}
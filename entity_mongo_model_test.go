@@ -3,10 +3,7 @@ package mgod_test
 import (
 	"context"
 	"testing"
-	"time"
 
-	"github.com/Lyearn/mgod"
-	"github.com/Lyearn/mgod/schema/schemaopt"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson"
@@ -20,19 +17,13 @@ type EntityMongoModelSuite struct {
 	*require.Assertions
 }
 
-type testEntity struct {
-	ID   string `bson:"_id" mgoType:"id"`
-	Name string
-	Age  *int `bson:",omitempty" mgoDefault:"18"`
-}
-
 func TestEntityMongoModelSuite(t *testing.T) {
 	s := new(EntityMongoModelSuite)
 	suite.Run(t, s)
 }
 
 func (s *EntityMongoModelSuite) SetupSuite() {
-	s.setupConnection()
+	connectTestClient(s.T())
 	s.setupData()
 }
 
@@ -41,23 +32,13 @@ func (s *EntityMongoModelSuite) SetupTest() {
 }
 
 func (s *EntityMongoModelSuite) TearDownSuite() {
-	entityMongoModel := s.getModel()
+	entityMongoModel := newTestEntityModel(s.T())
 	_, err := entityMongoModel.DeleteMany(context.Background(), bson.D{})
 	if err != nil {
 		s.T().Fatal(err)
 	}
 }
 
-func (s *EntityMongoModelSuite) setupConnection() {
-	cfg := &mgod.ConnectionConfig{Timeout: 5 * time.Second}
-	uri := "mongodb://localhost:27017/?replicaSet=replset&authSource=admin"
-
-	err := mgod.ConfigureDefaultClient(cfg, options.Client().ApplyURI(uri))
-	if err != nil {
-		s.T().Fatal(err)
-	}
-}
-
 func (s *EntityMongoModelSuite) setupData() {
 	firstID := primitive.NewObjectID()
 	secondID := primitive.NewObjectID()
@@ -78,29 +59,15 @@ func (s *EntityMongoModelSuite) setupData() {
 		},
 	}
 
-	entityMongoModel := s.getModel()
+	entityMongoModel := newTestEntityModel(s.T())
 	_, err := entityMongoModel.InsertMany(context.Background(), entities)
 	if err != nil {
 		s.T().Fatal(err)
 	}
 }
 
-func (s *EntityMongoModelSuite) getModel() mgod.EntityMongoModel[testEntity] {
-	dbName := "mgoddb"
-	collection := "entityMongoModel"
-	schemaOpts := schemaopt.SchemaOptions{Timestamps: true}
-
-	opts := mgod.NewEntityMongoModelOptions(dbName, collection, &schemaOpts)
-	model, err := mgod.NewEntityMongoModel(testEntity{}, *opts)
-	if err != nil {
-		s.T().Fatal(err)
-	}
-
-	return model
-}
-
 func (s *EntityMongoModelSuite) TestFind() {
-	entityMongoModel := s.getModel()
+	entityMongoModel := newTestEntityModel(s.T())
 	entities, err := entityMongoModel.Find(context.Background(), bson.M{
 		"age": bson.M{
 			"$gt": 20,
@@ -114,8 +81,47 @@ func (s *EntityMongoModelSuite) TestFind() {
 	s.Equal(2, len(entities))
 }
 
+func (s *EntityMongoModelSuite) TestFindCursor() {
+	entityMongoModel := newTestEntityModel(s.T())
+	cursor, err := entityMongoModel.FindCursor(context.Background(), bson.M{
+		"name": bson.M{
+			"$regex": "Default",
+		},
+	}, options.Find().SetBatchSize(1))
+
+	s.NoError(err)
+
+	names := []string{}
+	err = cursor.ForEach(context.Background(), func(entity testEntity) error {
+		names = append(names, entity.Name)
+		return nil
+	})
+
+	s.NoError(err)
+	s.ElementsMatch([]string{"Default User 1", "Default User 2"}, names)
+}
+
+func (s *EntityMongoModelSuite) TestAggregateCursor() {
+	entityMongoModel := newTestEntityModel(s.T())
+	cursor, err := entityMongoModel.AggregateCursor(context.Background(), mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.M{"name": bson.M{"$regex": "Default"}}}},
+		bson.D{{Key: "$project", Value: bson.M{"_id": 0, "name": 1}}},
+	}, options.Aggregate().SetBatchSize(1))
+
+	s.NoError(err)
+
+	names := []string{}
+	err = cursor.ForEach(context.Background(), func(doc bson.D) error {
+		names = append(names, doc.Map()["name"].(string))
+		return nil
+	})
+
+	s.NoError(err)
+	s.ElementsMatch([]string{"Default User 1", "Default User 2"}, names)
+}
+
 func (s *EntityMongoModelSuite) TestFindOne() {
-	entityMongoModel := s.getModel()
+	entityMongoModel := newTestEntityModel(s.T())
 	entity, err := entityMongoModel.FindOne(context.Background(), bson.M{
 		"age": bson.M{
 			"$gt": 30,
@@ -139,7 +145,7 @@ func (s *EntityMongoModelSuite) TestInsertOne() {
 		Age:  &age,
 	}
 
-	entityMongoModel := s.getModel()
+	entityMongoModel := newTestEntityModel(s.T())
 	doc, err := entityMongoModel.InsertOne(context.Background(), entity)
 
 	s.Nil(err)
@@ -152,3 +158,73 @@ func (s *EntityMongoModelSuite) TestInsertOne() {
 	s.NotNil(err)
 	s.True(mongo.IsDuplicateKeyError(err))
 }
+
+func (s *EntityMongoModelSuite) TestUpdateOne() {
+	entityMongoModel := newTestEntityModel(s.T())
+	entity := insertTestEntity(s.T(), "update-one")
+
+	result, err := entityMongoModel.UpdateOne(context.Background(), bson.M{"name": entity.Name}, bson.D{{
+		Key:   "$set",
+		Value: bson.D{{Key: "age", Value: 25}},
+	}})
+
+	s.NoError(err)
+	s.Equal(int64(1), result.ModifiedCount)
+
+	updatedEntity, err := entityMongoModel.FindOne(context.Background(), bson.M{"name": entity.Name})
+
+	s.NoError(err)
+	s.Equal(25, *updatedEntity.Age)
+}
+
+func (s *EntityMongoModelSuite) TestReplaceOne() {
+	entityMongoModel := newTestEntityModel(s.T())
+	entity := insertTestEntity(s.T(), "replace-one")
+
+	age := 50
+	entity.Name = "replace-one-replaced"
+	entity.Age = &age
+
+	result, err := entityMongoModel.ReplaceOne(context.Background(), bson.M{"name": "replace-one"}, entity)
+
+	s.NoError(err)
+	s.Equal(int64(1), result.ModifiedCount)
+
+	replacedEntity, err := entityMongoModel.FindOne(context.Background(), bson.M{"name": entity.Name})
+
+	s.NoError(err)
+	s.Equal(entity.ID, replacedEntity.ID)
+	s.Equal(50, *replacedEntity.Age)
+}
+
+func (s *EntityMongoModelSuite) TestFindOneAndReplace() {
+	entityMongoModel := newTestEntityModel(s.T())
+	entity := insertTestEntity(s.T(), "find-one-and-replace")
+
+	entity.Name = "find-one-and-replace-replaced"
+	entity.Age = nil
+
+	opts := options.FindOneAndReplace().SetReturnDocument(options.After)
+	replacedEntity, err := entityMongoModel.FindOneAndReplace(context.Background(), bson.M{"name": "find-one-and-replace"}, entity, opts)
+
+	s.NoError(err)
+	s.Equal(entity.ID, replacedEntity.ID)
+	s.Equal(entity.Name, replacedEntity.Name)
+	// default value is added to the replacement doc.
+	s.Equal(18, *replacedEntity.Age)
+}
+
+func (s *EntityMongoModelSuite) TestFindOneAndDelete() {
+	entityMongoModel := newTestEntityModel(s.T())
+	entity := insertTestEntity(s.T(), "find-one-and-delete")
+
+	deletedEntity, err := entityMongoModel.FindOneAndDelete(context.Background(), bson.M{"name": entity.Name})
+
+	s.NoError(err)
+	s.Equal(entity.ID, deletedEntity.ID)
+
+	count, err := entityMongoModel.CountDocuments(context.Background(), bson.M{"name": entity.Name})
+
+	s.NoError(err)
+	s.Equal(int64(0), count)
+}
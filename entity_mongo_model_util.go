@@ -9,6 +9,8 @@ import (
 	"github.com/Lyearn/mgod/errors"
 	"github.com/Lyearn/mgod/schema"
 	"github.com/Lyearn/mgod/schema/metafield"
+	"github.com/Lyearn/mgod/schema/schemaopt"
+	"github.com/samber/lo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -25,12 +27,84 @@ func GetSchemaCacheKey(collName, modelNameOrVal string) string {
 	return key
 }
 
+// getSchemaOptionsCacheKey returns the suffix of the schema cache key of a model identifying the schema options which
+// modify the schema i.e. the applicable meta fields along with their keys and the storage format of the timestamps.
+// This avoids sharing the schema between the models of the same entity and collection having different schema options.
+func getSchemaOptionsCacheKey(schemaOpts schemaopt.SchemaOptions) string {
+	keyElems := lo.Map(metafield.GetApplicableMetaFields(schemaOpts), func(metaField metafield.MetaField, _ int) string {
+		return string(metaField.GetKey())
+	})
+
+	if schemaOpts.Timestamps {
+		for _, timestampOpts := range []*schemaopt.TimestampOptions{schemaOpts.CreatedAt, schemaOpts.UpdatedAt} {
+			format := schemaopt.TimestampFormatDate
+			if timestampOpts != nil && timestampOpts.Format != "" {
+				format = timestampOpts.Format
+			}
+
+			keyElems = append(keyElems, string(format))
+		}
+	}
+
+	return strings.Join(keyElems, "_")
+}
+
 func (m entityMongoModel[T]) getEntityModel() T {
 	return m.modelType
 }
 
 // getMongoDocFromEntityModel converts the provided entity model to a bson.D doc.
 func (m entityMongoModel[T]) getMongoDocFromEntityModel(ctx context.Context, model T) (bson.D, error) {
+	bsonDoc, err := marshalEntityModel(model)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.buildMongoDoc(ctx, bsonDoc)
+}
+
+// getDocToInsertFromEntityModel runs the save hooks implemented by the provided entity model and converts it
+// to a bson.D doc to be inserted.
+func (m entityMongoModel[T]) getDocToInsertFromEntityModel(ctx context.Context, model T) (bson.D, error) {
+	if err := runBeforeSaveHooks(ctx, &model); err != nil {
+		return nil, err
+	}
+
+	return m.getMongoDocFromEntityModel(ctx, model)
+}
+
+// getReplacementDocFromEntityModel converts the provided entity model to a bson.D doc to be used as a replacement.
+// Unlike insertion, _id is never generated for a replacement doc because _id of an existing doc is immutable.
+// It also returns the doc version of the provided model (as it was loaded), which is nil if not present.
+func (m entityMongoModel[T]) getReplacementDocFromEntityModel(ctx context.Context, model T) (bson.D, interface{}, error) {
+	if err := runBeforeSaveHooks(ctx, &model); err != nil {
+		return nil, nil, err
+	}
+
+	bsonDoc, err := marshalEntityModel(model)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hasID := bsondoc.GetFieldValueFromRootDoc(&bsonDoc, "_id") != nil
+	docVersion := bsondoc.GetFieldValueFromRootDoc(&bsonDoc, m.getDocVersionKey())
+
+	bsonDoc, err = m.buildMongoDoc(ctx, bsonDoc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !hasID {
+		bsonDoc = lo.Filter(bsonDoc, func(elem bson.E, _ int) bool {
+			return elem.Key != "_id"
+		})
+	}
+
+	return bsonDoc, docVersion, nil
+}
+
+// marshalEntityModel converts the provided entity model to a bson.D doc without applying the schema.
+func marshalEntityModel(model interface{}) (bson.D, error) {
 	marshalledDoc, err := bson.Marshal(model)
 	if err != nil {
 		return nil, err
@@ -42,16 +116,21 @@ func (m entityMongoModel[T]) getMongoDocFromEntityModel(ctx context.Context, mod
 		return nil, err
 	}
 
+	return bsonDoc, nil
+}
+
+// buildMongoDoc adds meta fields to the provided bson.D doc and builds it according to the entity model schema.
+func (m entityMongoModel[T]) buildMongoDoc(ctx context.Context, bsonDoc bson.D) (bson.D, error) {
 	if bsonDoc == nil {
 		// empty bson doc
 		return bsonDoc, nil
 	}
 
-	if err = metafield.AddMetaFields(&bsonDoc, m.schemaOpts); err != nil {
+	if err := metafield.AddMetaFields(ctx, &bsonDoc, m.schemaOpts); err != nil {
 		return nil, err
 	}
 
-	err = bsondoc.Build(ctx, &bsonDoc, m.schema, bsondoc.TranslateToEnumMongo)
+	err := bsondoc.Build(ctx, &bsonDoc, m.schema, bsondoc.TranslateToEnumMongo)
 	if err != nil {
 		return nil, err
 	}
@@ -66,7 +145,7 @@ func (m entityMongoModel[T]) getMongoDocFromEntityModel(ctx context.Context, mod
 			})
 		}
 
-		cacheKey := GetSchemaCacheKey(m.coll.Name(), discriminatorVal.(string))
+		cacheKey := GetSchemaCacheKey(m.collName, discriminatorVal.(string))
 		if _, err := schema.EntityModelSchemaCacheInstance.GetSchema(cacheKey); err != nil {
 			schema.EntityModelSchemaCacheInstance.SetSchema(cacheKey, m.schema)
 		}
@@ -89,7 +168,7 @@ func (m entityMongoModel[T]) getEntityModelFromMongoDoc(ctx context.Context, bso
 	if m.isUnionType {
 		discriminatorVal := bsondoc.GetFieldValueFromRootDoc(&bsonDoc, m.discriminatorKey)
 		if discriminatorVal != nil {
-			cacheKey := GetSchemaCacheKey(m.coll.Name(), discriminatorVal.(string))
+			cacheKey := GetSchemaCacheKey(m.collName, discriminatorVal.(string))
 			if unionElemSchema, err := schema.EntityModelSchemaCacheInstance.GetSchema(cacheKey); err == nil {
 				entityModelSchema = unionElemSchema
 			}
@@ -114,63 +193,270 @@ func (m entityMongoModel[T]) getEntityModelFromMongoDoc(ctx context.Context, bso
 	return model, nil
 }
 
-// handleTimestampsForUpdateQuery adds updatedAt field to the update query if the schema options has timestamps enabled.
-func (m entityMongoModel[T]) handleTimestampsForUpdateQuery(update interface{}, funcName string) (interface{}, error) {
+// getDB returns the database of the entity (using the client of the entity) for the provided context. The database is chosen using the db resolver
+// (if provided), falling back to the database provided in the model options.
+// It returns an error if the client of the entity is not registered anymore e.g. after disconnecting it.
+func (m entityMongoModel[T]) getDB(ctx context.Context) (*mongo.Database, error) {
+	dbName := m.dbName
+
+	if m.dbResolver != nil {
+		if resolvedDBName := m.dbResolver(ctx); resolvedDBName != "" {
+			dbName = resolvedDBName
+		}
+	}
+
+	return getDBConn(m.clientName, dbName)
+}
+
+// getCollection returns the collection of the entity for the provided context.
+func (m entityMongoModel[T]) getCollection(ctx context.Context) (*mongo.Collection, error) {
+	db, err := m.getDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.Collection(m.collName), nil
+}
+
+// buildFilter translates the provided filter query according to the entity model schema.
+func (m entityMongoModel[T]) buildFilter(ctx context.Context, filter interface{}) (interface{}, error) {
+	return bsondoc.BuildFilter(ctx, filter, m.schema)
+}
+
+// getEntityModelFromFoundDoc converts the provided bson.D doc returned by a find operation to an entity model.
+// Unlike getEntityModelFromMongoDoc, it also runs the AfterFind hooks.
+func (m entityMongoModel[T]) getEntityModelFromFoundDoc(ctx context.Context, bsonDoc bson.D) (T, error) {
+	if err := runHooks(ctx, m.hooks, afterFindHook, bsonDoc); err != nil {
+		return m.getEntityModel(), err
+	}
+
+	model, err := m.getEntityModelFromMongoDoc(ctx, bsonDoc)
+	if err != nil {
+		return model, err
+	}
+
+	if err = runAfterFindHook(ctx, &model); err != nil {
+		return model, err
+	}
+
+	return model, nil
+}
+
+// decodeSingleResult converts the doc returned by a single document operation to an entity model.
+func (m entityMongoModel[T]) decodeSingleResult(ctx context.Context, result *mongo.SingleResult) (T, error) {
+	var doc bson.D
+
+	if err := result.Decode(&doc); err != nil {
+		return m.getEntityModel(), err
+	}
+
+	return m.getEntityModelFromFoundDoc(ctx, doc)
+}
+
+// buildUpdateQuery translates the provided update query according to the entity model schema and adds the
+// meta fields related update commands to it.
+func (m entityMongoModel[T]) buildUpdateQuery(ctx context.Context, update interface{}, funcName string) (bson.D, error) {
+	updateQuery, err := bsondoc.BuildUpdate(ctx, update, m.schema)
+	if err != nil {
+		return nil, err
+	}
+
+	metaFieldsUpdateQuery, err := m.handleMetaFieldsForUpdateQuery(ctx, updateQuery, funcName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = m.checkTenantUpdate(ctx, metaFieldsUpdateQuery); err != nil {
+		return nil, err
+	}
+
+	return m.handleDocVersionForUpdateQuery(metaFieldsUpdateQuery), nil
+}
+
+// handleMetaFieldsForUpdateQuery adds the update query operations of the applicable meta fields (e.g. updatedAt if the
+// schema options has timestamps enabled) to the update query.
+func (m entityMongoModel[T]) handleMetaFieldsForUpdateQuery(ctx context.Context, update interface{}, funcName string) (bson.D, error) {
 	updateQuery, ok := update.(bson.D)
 	if !ok {
 		return nil, errors.NewBadRequestError(errors.BadRequestError{
-			Underlying: "update query",
+			Underlying: fmt.Sprintf("%s update query", funcName),
 			Got:        fmt.Sprintf("%T", update),
 			Expected:   "bson.D",
 		})
 	}
 
-	if m.schemaOpts.Timestamps {
-		updatedAtCommand := bson.E{
-			Key: "$currentDate",
-			Value: bson.D{{
-				Key:   "updatedAt",
-				Value: true,
-			}},
+	for _, metaField := range metafield.GetApplicableMetaFields(m.schemaOpts) {
+		operation, err := metaField.GetUpdateQueryOperation(ctx)
+		if err != nil {
+			return nil, err
+		} else if operation == nil {
+			continue
 		}
 
-		updateQuery = append(updateQuery, updatedAtCommand)
+		updateQuery = addToUpdateOperator(updateQuery, operation.Operator, bson.E{
+			Key:   string(metaField.GetKey()),
+			Value: operation.Value,
+		})
 	}
 
 	return updateQuery, nil
 }
 
 // transformToBulkWriteBSONDocs converts bulkWrite entity models to mongo models.
+// Delete models are converted to update models setting the deleted at meta field if soft delete is enabled.
 func (m entityMongoModel[T]) transformToBulkWriteBSONDocs(ctx context.Context, bulkWrites []mongo.WriteModel) error {
-	for _, bulkWrite := range bulkWrites {
+	for idx, bulkWrite := range bulkWrites {
 		switch bulkWriteType := bulkWrite.(type) {
 		case *mongo.InsertOneModel:
-			doc := bulkWriteType.Document
-			if doc == nil {
+			if model, ok := bulkWriteType.Document.(T); ok {
+				bsonDoc, err := m.getDocToInsertFromEntityModel(ctx, model)
+				if err != nil {
+					return err
+				}
+
+				bulkWriteType.Document = bsonDoc
+
 				continue
 			}
 
-			bsonDoc, err := m.getMongoDocFromEntityModel(ctx, doc.(T))
+			bsonDoc, err := m.getBulkWriteDocForTenant(ctx, bulkWriteType.Document)
 			if err != nil {
 				return err
 			}
 
 			bulkWriteType.Document = bsonDoc
 		case *mongo.UpdateOneModel:
-			updateQuery, err := m.handleTimestampsForUpdateQuery(bulkWriteType.Update, "BulkWrite")
+			filterQuery, err := m.buildTenantFilter(ctx, bulkWriteType.Filter)
+			if err != nil {
+				return err
+			}
+
+			updateQuery, err := m.buildUpdateQuery(ctx, bulkWriteType.Update, "BulkWrite")
 			if err != nil {
 				return err
 			}
 
+			bulkWriteType.Filter = filterQuery
 			bulkWriteType.Update = updateQuery
 		case *mongo.UpdateManyModel:
-			updateQuery, err := m.handleTimestampsForUpdateQuery(bulkWriteType.Update, "BulkWrite")
+			filterQuery, err := m.buildTenantFilter(ctx, bulkWriteType.Filter)
 			if err != nil {
 				return err
 			}
 
+			updateQuery, err := m.buildUpdateQuery(ctx, bulkWriteType.Update, "BulkWrite")
+			if err != nil {
+				return err
+			}
+
+			bulkWriteType.Filter = filterQuery
 			bulkWriteType.Update = updateQuery
+		case *mongo.ReplaceOneModel:
+			filterQuery, err := m.buildTenantFilter(ctx, bulkWriteType.Filter)
+			if err != nil {
+				return err
+			}
+
+			model, ok := bulkWriteType.Replacement.(T)
+			if !ok {
+				if m.isOptimisticConcurrencyEnabled() {
+					// version of a replacement doc which is not an entity model is unknown.
+					return errors.NewBadRequestError(errors.BadRequestError{
+						Underlying: "BulkWrite replacement doc",
+						Got:        fmt.Sprintf("%T", bulkWriteType.Replacement),
+						Expected:   fmt.Sprintf("%T for optimistic concurrency", model),
+					})
+				}
+
+				replacement, err := m.getBulkWriteDocForTenant(ctx, bulkWriteType.Replacement)
+				if err != nil {
+					return err
+				}
+
+				bulkWriteType.Filter = filterQuery
+				bulkWriteType.Replacement = replacement
+
+				continue
+			}
+
+			replacement, docVersion, err := m.getReplacementDocFromEntityModel(ctx, model)
+			if err != nil {
+				return err
+			}
+
+			versionedFilterQuery, err := m.addDocVersionToReplaceFilter(filterQuery, docVersion)
+			if err != nil {
+				return err
+			}
+
+			bulkWriteType.Filter = versionedFilterQuery
+			bulkWriteType.Replacement = replacement
+		case *mongo.DeleteOneModel:
+			if m.schemaOpts.SoftDelete {
+				filterQuery, updateQuery, err := m.getSoftDeleteQueries(ctx, bulkWriteType.Filter, "BulkWrite")
+				if err != nil {
+					return err
+				}
+
+				bulkWrites[idx] = &mongo.UpdateOneModel{
+					Filter:    filterQuery,
+					Update:    updateQuery,
+					Collation: bulkWriteType.Collation,
+					Hint:      bulkWriteType.Hint,
+				}
+
+				continue
+			}
+
+			filterQuery, err := m.buildTenantFilter(ctx, bulkWriteType.Filter)
+			if err != nil {
+				return err
+			}
+
+			bulkWriteType.Filter = filterQuery
+		case *mongo.DeleteManyModel:
+			if m.schemaOpts.SoftDelete {
+				filterQuery, updateQuery, err := m.getSoftDeleteQueries(ctx, bulkWriteType.Filter, "BulkWrite")
+				if err != nil {
+					return err
+				}
+
+				bulkWrites[idx] = &mongo.UpdateManyModel{
+					Filter:    filterQuery,
+					Update:    updateQuery,
+					Collation: bulkWriteType.Collation,
+					Hint:      bulkWriteType.Hint,
+				}
+
+				continue
+			}
+
+			filterQuery, err := m.buildTenantFilter(ctx, bulkWriteType.Filter)
+			if err != nil {
+				return err
+			}
+
+			bulkWriteType.Filter = filterQuery
 		}
 	}
 	return nil
 }
+
+// getBulkWriteDocForTenant returns the doc of a bulkWrite model which is not an entity model (e.g. a bson.D or bson.M
+// doc) with the tenant resolved from the provided context added to it. See [entityMongoModel.addTenantToDoc].
+// The doc is returned as it is if tenancy is disabled for the entity.
+func (m entityMongoModel[T]) getBulkWriteDocForTenant(ctx context.Context, doc interface{}) (interface{}, error) {
+	if m.schemaOpts.Tenancy == nil {
+		return doc, nil
+	}
+
+	bsonDoc, ok := doc.(bson.D)
+	if !ok {
+		var err error
+		if bsonDoc, err = marshalEntityModel(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	return m.addTenantToDoc(ctx, bsonDoc)
+}
@@ -9,26 +9,148 @@ func (e Error) Error() string {
 	return string(e)
 }
 
+// BadRequestError is returned when the provided input (e.g. a filter or an update query) is not valid.
+// Its details are available using errors.As.
 type BadRequestError struct {
 	Expected   string
 	Got        string
 	Underlying string
 }
 
-func NewBadRequestError(e BadRequestError) Error {
-	return Error(fmt.Sprintf("%s: expected %s, got %s", e.Underlying, e.Expected, e.Got))
+func (e BadRequestError) Error() string {
+	return fmt.Sprintf("%s: expected %s, got %s", e.Underlying, e.Expected, e.Got)
 }
 
+func NewBadRequestError(e BadRequestError) BadRequestError {
+	return e
+}
+
+// NotFoundError is returned when the provided value (e.g. a client name or a schema path) is not found.
+// It matches [ErrNotFound] using errors.Is and its details are available using errors.As.
 type NotFoundError struct {
 	Value      string
 	Underlying string
 }
 
-func NewNotFoundError(e NotFoundError) Error {
-	return Error(fmt.Sprintf("%s not found for %s", e.Value, e.Underlying))
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("%s not found for %s", e.Value, e.Underlying)
+}
+
+func (e NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+func NewNotFoundError(e NotFoundError) NotFoundError {
+	return e
+}
+
+// AlreadyExistsError is returned when the provided value (e.g. a client name) is already registered.
+// It matches [ErrAlreadyExists] using errors.Is and its details are available using errors.As.
+type AlreadyExistsError struct {
+	Value      string
+	Underlying string
+}
+
+func (e AlreadyExistsError) Error() string {
+	return fmt.Sprintf("%s already exists in %s", e.Value, e.Underlying)
+}
+
+func (e AlreadyExistsError) Is(target error) bool {
+	return target == ErrAlreadyExists
+}
+
+func NewAlreadyExistsError(e AlreadyExistsError) AlreadyExistsError {
+	return e
+}
+
+// ValidationError is returned when the value of a field violates one of its validation rules.
+// It matches [ErrValidation] using errors.Is.
+type ValidationError struct {
+	// Path is the schema path of the field.
+	Path string
+	// Rule is the name of the violated rule.
+	Rule string
+	// Param is the parameter of the violated rule.
+	Param string
+	// Value is the value which violates the rule.
+	Value interface{}
+}
+
+func (e ValidationError) Error() string {
+	rule := e.Rule
+	if e.Param != "" {
+		rule = fmt.Sprintf("%s=%s", e.Rule, e.Param)
+	}
+
+	return fmt.Sprintf("%s: value %v at path - %s violates %s rule", ErrValidation, e.Value, e.Path, rule)
+}
+
+func (e ValidationError) Is(target error) bool {
+	return target == ErrValidation
 }
 
 const (
 	ErrNoDatabaseConnection = Error("no database connection")
 	ErrSchemaNotCached      = Error("schema not cached")
+	ErrVersionConflict      = Error("version conflict")
+	ErrValidation           = Error("validation failed")
+	ErrTenantNotFound       = Error("tenant not found in context")
+	ErrCrossTenantWrite     = Error("cross tenant write")
+	ErrNotFound             = Error("not found")
+	ErrAlreadyExists        = Error("already exists")
+	ErrDuplicateKey         = Error("duplicate key")
 )
+
+// OperationError is returned by the operations of an EntityMongoModel. It carries the name of the operation and the
+// details of the failure, and wraps the underlying (driver) error.
+//
+// It matches the sentinel error of its kind (e.g. [ErrNotFound], [ErrDuplicateKey], [ErrValidation] or
+// [ErrVersionConflict]) using errors.Is, and the wrapped error is available using errors.As or errors.Unwrap.
+type OperationError struct {
+	// Op is the name of the operation e.g. InsertOne.
+	Op string
+	// Kind is the sentinel error describing the kind of the failure. It is nil if the failure is not classified.
+	Kind error
+	// Path is the schema path of the field causing the failure (if applicable).
+	Path string
+	// Expected is the expected value causing the failure (if applicable).
+	Expected string
+	// Got is the provided value causing the failure (if applicable).
+	Got string
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *OperationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Op, e.Err.Error())
+}
+
+func (e *OperationError) Unwrap() error {
+	return e.Err
+}
+
+func (e *OperationError) Is(target error) bool {
+	return e.Kind != nil && target == e.Kind
+}
+
+// DuplicateKeyError is returned when a write violates a unique index. It matches [ErrDuplicateKey] using errors.Is.
+type DuplicateKeyError struct {
+	// Index is the name of the violated unique index.
+	Index string
+	// Keys are the duplicate values of the index keys. It is nil if the server doesn't report them.
+	Keys map[string]interface{}
+	// Err is the underlying driver error.
+	Err error
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return fmt.Sprintf("%s: index %s, keys %v: %s", ErrDuplicateKey, e.Index, e.Keys, e.Err.Error())
+}
+
+func (e *DuplicateKeyError) Unwrap() error {
+	return e.Err
+}
+
+func (e *DuplicateKeyError) Is(target error) bool {
+	return target == ErrDuplicateKey
+}
@@ -0,0 +1,22 @@
+package mgod
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TransformToBulkWriteBSONDocs exposes the conversion of the bulk write models of the provided model for the tests.
+func TransformToBulkWriteBSONDocs[T any](ctx context.Context, model EntityMongoModel[T], bulkWrites []mongo.WriteModel) error {
+	return model.(*entityMongoModel[T]).transformToBulkWriteBSONDocs(ctx, bulkWrites)
+}
+
+// ScopePipeline exposes the scoping of the aggregation pipelines of the provided model for the tests.
+func ScopePipeline[T any](ctx context.Context, model EntityMongoModel[T], pipeline interface{}) (interface{}, error) {
+	return model.(*entityMongoModel[T]).scopePipeline(ctx, pipeline)
+}
+
+// SetClient exposes the replacement of the client registered with the provided name for the tests.
+func SetClient(name string, client *mongo.Client) {
+	setClient(name, client, nil)
+}
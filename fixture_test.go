@@ -0,0 +1,146 @@
+package mgod_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Lyearn/mgod"
+	"github.com/Lyearn/mgod/schema/schemaopt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// testDBName is the database used by the tests.
+const testDBName = "mgoddb"
+
+type testEntity struct {
+	ID   string `bson:"_id" mgoType:"id"`
+	Name string
+	Age  *int `bson:",omitempty" mgoDefault:"18"`
+}
+
+type testVersionedEntity struct {
+	ID      string `bson:"_id" mgoType:"id"`
+	Name    string
+	Version int `bson:"__v"`
+}
+
+type testHookedEntity struct {
+	ID        string `bson:"_id" mgoType:"id"`
+	Name      string
+	CreatedBy string `bson:"createdBy"`
+	Loaded    bool   `bson:"-"`
+}
+
+func (e testHookedEntity) Validate() error {
+	if e.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	return nil
+}
+
+func (e *testHookedEntity) BeforeSave(_ context.Context) error {
+	e.CreatedBy = "gopher"
+	return nil
+}
+
+func (e *testHookedEntity) AfterFind(_ context.Context) error {
+	e.Loaded = true
+	return nil
+}
+
+var (
+	testClientOnce sync.Once
+	testClientErr  error
+)
+
+// connectTestClient configures the default client connected to the local replica set. The client is configured once
+// and shared by all the tests needing a MongoDB server.
+func connectTestClient(t *testing.T) {
+	t.Helper()
+
+	testClientOnce.Do(func() {
+		cfg := &mgod.ConnectionConfig{Timeout: 5 * time.Second}
+		// Can use the `mlaunch` tool to start a local replica set using command `mlaunch --repl`.
+		uri := "mongodb://localhost:27017/?replicaSet=replset&authSource=admin"
+
+		testClientErr = mgod.ConfigureDefaultClient(cfg, options.Client().ApplyURI(uri))
+	})
+
+	if testClientErr != nil {
+		t.Fatal(testClientErr)
+	}
+}
+
+// registerTestClient registers a new client with a unique name having the provided prefix. The driver connects lazily,
+// so no server is needed unless an operation is executed.
+func registerTestClient(prefix string) (string, error) {
+	client, err := mongo.Connect(context.Background(), options.Client().
+		ApplyURI("mongodb://localhost:27017").
+		SetServerSelectionTimeout(time.Second))
+	if err != nil {
+		return "", err
+	}
+
+	clientName := fmt.Sprintf("%s_%s", prefix, primitive.NewObjectID().Hex())
+
+	return clientName, mgod.RegisterClient(clientName, client)
+}
+
+// newTestModel returns a model of the provided entity stored in the provided collection of the test database using
+// the default client.
+func newTestModel[T any](t *testing.T, entity T, collection string, schemaOpts *schemaopt.SchemaOptions,
+	hooks ...mgod.ModelHooks,
+) mgod.EntityMongoModel[T] {
+	t.Helper()
+
+	connectTestClient(t)
+
+	return newTestModelForClient(t, mgod.DefaultClientName, entity, collection, schemaOpts, hooks...)
+}
+
+// newTestModelForClient returns a model of the provided entity stored in the provided collection of the test database
+// using the provided client.
+func newTestModelForClient[T any](t *testing.T, clientName string, entity T, collection string,
+	schemaOpts *schemaopt.SchemaOptions, hooks ...mgod.ModelHooks,
+) mgod.EntityMongoModel[T] {
+	t.Helper()
+
+	opts := mgod.NewEntityMongoModelOptions(testDBName, collection, schemaOpts).
+		SetClientName(clientName).
+		AddHooks(hooks...)
+
+	model, err := mgod.NewEntityMongoModel(entity, *opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return model
+}
+
+// newTestEntityModel returns the model of testEntity (with timestamps) whose collection is shared by the tests.
+func newTestEntityModel(t *testing.T) mgod.EntityMongoModel[testEntity] {
+	t.Helper()
+
+	return newTestModel(t, testEntity{}, "entityMongoModel", &schemaopt.SchemaOptions{Timestamps: true})
+}
+
+// insertTestEntity inserts a testEntity with the provided name in the collection shared by the tests.
+func insertTestEntity(t *testing.T, name string) testEntity {
+	t.Helper()
+
+	entity, err := newTestEntityModel(t).InsertOne(context.Background(), testEntity{
+		ID:   primitive.NewObjectID().Hex(),
+		Name: name,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return entity
+}
@@ -0,0 +1,229 @@
+package mgod
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// ClientHealth is the health of a MongoDB client.
+type ClientHealth struct {
+	// Reachable reports whether any server of the deployment responds to a ping.
+	Reachable bool `json:"reachable"`
+	// TopologyKind is the kind of the deployment e.g. ReplicaSetWithPrimary, ReplicaSetNoPrimary, Sharded etc.
+	// It is Unknown if the topology is not monitored (see [NewServerMonitor]).
+	TopologyKind string `json:"topologyKind"`
+	// PrimaryAvailable reports whether a server accepting writes (primary, standalone or mongos) is available.
+	PrimaryAvailable bool `json:"primaryAvailable"`
+	// ServerVersion is the version of the MongoDB server.
+	ServerVersion string `json:"serverVersion,omitempty"`
+	// Error is the error encountered while checking the health (if any).
+	Error string `json:"error,omitempty"`
+}
+
+// IsReady reports whether the client can serve both the reads and the writes.
+func (h ClientHealth) IsReady() bool {
+	return h.Reachable && h.PrimaryAvailable
+}
+
+var (
+	topologyMu sync.RWMutex
+	// topologyTrackers are the trackers of the latest topology descriptions of the clients keyed by the client name.
+	topologyTrackers = map[string]*topologyTracker{}
+)
+
+// NewServerMonitor returns a server monitor tracking the topology of the provided client, which is reported by
+// [HealthCheck]. It should be set in the client options while creating a client outside of mgod. Events are forwarded
+// to the provided next monitor (if any).
+func NewServerMonitor(clientName string, next *event.ServerMonitor) *event.ServerMonitor {
+	tracker := &topologyTracker{}
+	setTopologyTracker(clientName, tracker)
+
+	return tracker.serverMonitor(next)
+}
+
+// setTopologyTracker sets the topology tracker of the provided client, replacing the existing one.
+func setTopologyTracker(clientName string, tracker *topologyTracker) {
+	topologyMu.Lock()
+	defer topologyMu.Unlock()
+
+	topologyTrackers[clientName] = tracker
+}
+
+// deleteTopology removes the tracked topology of the provided client.
+func deleteTopology(clientName string) {
+	topologyMu.Lock()
+	defer topologyMu.Unlock()
+
+	delete(topologyTrackers, clientName)
+}
+
+// getTopologyTracker returns the topology tracker of the provided client, or nil if it is not monitored.
+func getTopologyTracker(clientName string) *topologyTracker {
+	topologyMu.RLock()
+	defer topologyMu.RUnlock()
+
+	return topologyTrackers[clientName]
+}
+
+// deleteReplacedTopology removes the provided topology tracker of a replaced client, unless it has been replaced by
+// the tracker of the new client.
+func deleteReplacedTopology(clientName string, tracker *topologyTracker) {
+	topologyMu.Lock()
+	defer topologyMu.Unlock()
+
+	if topologyTrackers[clientName] == tracker {
+		delete(topologyTrackers, clientName)
+	}
+}
+
+// getTopology returns the latest topology description of the provided client, or nil if it is not monitored.
+func getTopology(clientName string) *description.Topology {
+	tracker := getTopologyTracker(clientName)
+	if tracker == nil {
+		return nil
+	}
+
+	return tracker.getTopology()
+}
+
+// topologyTracker tracks the latest topology description of a client.
+type topologyTracker struct {
+	mu       sync.RWMutex
+	topology *description.Topology
+}
+
+// serverMonitor returns a server monitor updating the tracked topology before forwarding the events to the provided
+// next monitor (if any).
+func (t *topologyTracker) serverMonitor(next *event.ServerMonitor) *event.ServerMonitor {
+	monitor := &event.ServerMonitor{}
+	if next != nil {
+		*monitor = *next
+	}
+
+	monitor.TopologyDescriptionChanged = func(evt *event.TopologyDescriptionChangedEvent) {
+		topology := evt.NewDescription
+
+		t.mu.Lock()
+		t.topology = &topology
+		t.mu.Unlock()
+
+		if next != nil && next.TopologyDescriptionChanged != nil {
+			next.TopologyDescriptionChanged(evt)
+		}
+	}
+
+	return monitor
+}
+
+func (t *topologyTracker) getTopology() *description.Topology {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.topology
+}
+
+// HealthCheck returns the health of all the registered clients keyed by the client name.
+func HealthCheck(ctx context.Context) map[string]ClientHealth {
+	clientsMu.RLock()
+	clientNames := lo.Keys(clients)
+	clientsMu.RUnlock()
+
+	health := make(map[string]ClientHealth, len(clientNames))
+	for _, clientName := range clientNames {
+		health[clientName] = checkClientHealth(ctx, clientName)
+	}
+
+	return health
+}
+
+// checkClientHealth returns the health of the provided client.
+func checkClientHealth(ctx context.Context, clientName string) ClientHealth {
+	health := ClientHealth{TopologyKind: "Unknown"}
+
+	client, err := getClient(clientName)
+	if err != nil {
+		health.Error = err.Error()
+		return health
+	}
+
+	if err = client.Ping(ctx, readpref.Nearest()); err != nil {
+		health.Error = err.Error()
+		return health
+	}
+
+	health.Reachable = true
+
+	if topology := getTopology(clientName); topology != nil {
+		health.TopologyKind = topology.Kind.String()
+		health.PrimaryAvailable = hasWritableServer(topology)
+	} else {
+		// topology is not monitored. hence, checking the primary by pinging it.
+		health.PrimaryAvailable = client.Ping(ctx, readpref.Primary()) == nil
+	}
+
+	var buildInfo struct {
+		Version string `bson:"version"`
+	}
+
+	err = client.Database("admin").RunCommand(ctx, bson.D{{Key: "buildInfo", Value: 1}}, nil).Decode(&buildInfo)
+	if err != nil {
+		health.Error = err.Error()
+		return health
+	}
+
+	health.ServerVersion = buildInfo.Version
+
+	return health
+}
+
+// hasWritableServer reports whether the provided topology has a server accepting writes.
+func hasWritableServer(topology *description.Topology) bool {
+	for _, server := range topology.Servers {
+		switch server.Kind {
+		case description.RSPrimary, description.Standalone, description.Mongos, description.LoadBalancer:
+			return true
+		}
+	}
+
+	return false
+}
+
+// HealthHandler returns an http.Handler serving the health of the registered clients as JSON on the following paths -
+//
+//   - /healthz responds with 200 if all the clients are reachable, else 503.
+//   - /readyz responds with 200 if all the clients are reachable and have a primary available, else 503.
+//
+// Other paths respond with 404. The handler can be mounted on any prefix using http.StripPrefix.
+func HealthHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeHealth(w, HealthCheck(r.Context()), func(h ClientHealth) bool { return h.Reachable })
+	})
+
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		writeHealth(w, HealthCheck(r.Context()), ClientHealth.IsReady)
+	})
+
+	return mux
+}
+
+// writeHealth writes the provided health of the clients with 503 status code if any client is not healthy.
+func writeHealth(w http.ResponseWriter, health map[string]ClientHealth, isHealthy func(h ClientHealth) bool) {
+	statusCode := http.StatusOK
+	if !lo.EveryBy(lo.Values(health), isHealthy) {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(health)
+}
@@ -0,0 +1,44 @@
+package mgod_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Lyearn/mgod"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func TestHealthSuite(t *testing.T) {
+	s := new(HealthSuite)
+	suite.Run(t, s)
+}
+
+func (s *HealthSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *HealthSuite) TestHealthCheck() {
+	connectTestClient(s.T())
+
+	health, ok := mgod.HealthCheck(context.Background())[mgod.DefaultClientName]
+	s.True(ok)
+	s.True(health.Reachable)
+	s.True(health.PrimaryAvailable)
+	s.Equal("ReplicaSetWithPrimary", health.TopologyKind)
+	s.NotEmpty(health.ServerVersion)
+
+	handler := mgod.HealthHandler()
+	for _, path := range []string{"/healthz", "/readyz"} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		s.Equal(http.StatusOK, recorder.Code)
+	}
+}
@@ -0,0 +1,319 @@
+package mgod
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Lyearn/mgod/errors"
+	"github.com/Lyearn/mgod/schema"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// defaultIndexName is the name of the index MongoDB creates on the _id field.
+const defaultIndexName = "_id_"
+
+// IndexDiff is the difference between the indexes declared on the entity model and the indexes present in the collection.
+type IndexDiff struct {
+	// Missing are the declared indexes which are not present in the collection.
+	Missing []mongo.IndexModel
+	// Extra are the names of the indexes present in the collection which are not declared on the entity model.
+	Extra []string
+	// Mismatched are the declared indexes for which an index with the same name but different keys or
+	// options is present in the collection.
+	Mismatched []mongo.IndexModel
+}
+
+// IsEmpty reports whether the declared indexes are in sync with the indexes present in the collection.
+func (d IndexDiff) IsEmpty() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0 && len(d.Mismatched) == 0
+}
+
+// indexDefinition is the normalized form of an index used to compare the declared and existing indexes.
+type indexDefinition struct {
+	Name                    string `bson:"name"`
+	Key                     bson.D `bson:"key"`
+	Unique                  bool   `bson:"unique"`
+	Sparse                  bool   `bson:"sparse"`
+	ExpireAfterSeconds      *int32 `bson:"expireAfterSeconds"`
+	PartialFilterExpression bson.D `bson:"partialFilterExpression"`
+	// Weights holds the text indexed fields. MongoDB replaces the text fields in the index key with _fts and _ftsx.
+	Weights bson.D `bson:"weights"`
+}
+
+func (m entityMongoModel[T]) EnsureIndexes(ctx context.Context) (err error) {
+	defer wrapOperationError("EnsureIndexes", &err)
+
+	diff, err := m.diffIndexes(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(diff.Mismatched) > 0 {
+		mismatchedNames := lo.Map(diff.Mismatched, func(model mongo.IndexModel, _ int) string {
+			return *model.Options.Name
+		})
+
+		return errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: "ensure indexes",
+			Got:        fmt.Sprintf("mismatched indexes - %s", strings.Join(mismatchedNames, ", ")),
+			Expected:   "existing indexes to match the declared indexes",
+		})
+	}
+
+	if len(diff.Missing) == 0 {
+		return nil
+	}
+
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = coll.Indexes().CreateMany(ctx, diff.Missing)
+
+	return err
+}
+
+func (m entityMongoModel[T]) DiffIndexes(ctx context.Context) (_ IndexDiff, err error) {
+	defer wrapOperationError("DiffIndexes", &err)
+
+	return m.diffIndexes(ctx)
+}
+
+// diffIndexes is the implementation of DiffIndexes which doesn't wrap the returned error, so that it can be used by
+// EnsureIndexes.
+func (m entityMongoModel[T]) diffIndexes(ctx context.Context) (IndexDiff, error) {
+	diff := IndexDiff{}
+
+	declaredIndexes := m.getDeclaredIndexes()
+
+	existingIndexes, err := m.getExistingIndexes(ctx)
+	if err != nil {
+		return diff, err
+	}
+
+	existingIndexByName := lo.KeyBy(existingIndexes, func(index indexDefinition) string {
+		return index.Name
+	})
+
+	for _, declaredIndex := range declaredIndexes {
+		existingIndex, ok := existingIndexByName[declaredIndex.Name]
+
+		switch {
+		case !ok:
+			diff.Missing = append(diff.Missing, declaredIndex.toIndexModel())
+		case !declaredIndex.isEqual(existingIndex):
+			diff.Mismatched = append(diff.Mismatched, declaredIndex.toIndexModel())
+		}
+	}
+
+	declaredIndexNames := lo.Map(declaredIndexes, func(index indexDefinition, _ int) string {
+		return index.Name
+	})
+
+	for _, existingIndex := range existingIndexes {
+		if existingIndex.Name != defaultIndexName && !lo.Contains(declaredIndexNames, existingIndex.Name) {
+			diff.Extra = append(diff.Extra, existingIndex.Name)
+		}
+	}
+
+	return diff, nil
+}
+
+// getDeclaredIndexes returns the indexes declared on the entity model fields in the order of the schema tree.
+func (m entityMongoModel[T]) getDeclaredIndexes() []indexDefinition {
+	indexes := []indexDefinition{}
+	indexIdxByName := map[string]int{}
+
+	var collectIndexes func(nodes []schema.TreeNode)
+	collectIndexes = func(nodes []schema.TreeNode) {
+		for _, node := range nodes {
+			fieldPath := schema.GetFieldPathFromSchemaPath(node.Path)
+
+			for _, spec := range node.Props.Options.Index {
+				var keyVal interface{} = spec.Order
+				if spec.Text {
+					keyVal = "text"
+				}
+
+				key := bson.E{Key: fieldPath, Value: keyVal}
+
+				if idx, ok := indexIdxByName[spec.Name]; ok && spec.Name != "" {
+					// compound index
+					index := &indexes[idx]
+					index.Key = append(index.Key, key)
+					index.Unique = index.Unique || spec.Unique
+					index.Sparse = index.Sparse || spec.Sparse
+
+					if index.ExpireAfterSeconds == nil {
+						index.ExpireAfterSeconds = spec.TTL
+					}
+
+					if index.PartialFilterExpression == nil {
+						index.PartialFilterExpression = spec.PartialFilter
+					}
+
+					continue
+				}
+
+				indexes = append(indexes, indexDefinition{
+					Name:                    spec.Name,
+					Key:                     bson.D{key},
+					Unique:                  spec.Unique,
+					Sparse:                  spec.Sparse,
+					ExpireAfterSeconds:      spec.TTL,
+					PartialFilterExpression: spec.PartialFilter,
+				})
+
+				if spec.Name != "" {
+					indexIdxByName[spec.Name] = len(indexes) - 1
+				}
+			}
+
+			collectIndexes(node.Children)
+		}
+	}
+
+	collectIndexes(m.schema.Root.Children)
+
+	for idx := range indexes {
+		if indexes[idx].Name == "" {
+			indexes[idx].Name = getDefaultIndexName(indexes[idx].Key)
+		}
+	}
+
+	return indexes
+}
+
+// getExistingIndexes returns the indexes present in the collection.
+func (m entityMongoModel[T]) getExistingIndexes(ctx context.Context) ([]indexDefinition, error) {
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := coll.Indexes().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var indexes []indexDefinition
+	if err = cursor.All(ctx, &indexes); err != nil {
+		return nil, err
+	}
+
+	return indexes, nil
+}
+
+// getDefaultIndexName returns the name MongoDB generates for an index with the provided keys.
+// e.g. {email: 1, createdAt: -1} => email_1_createdAt_-1
+func getDefaultIndexName(keys bson.D) string {
+	nameParts := lo.Map(keys, func(key bson.E, _ int) string {
+		return fmt.Sprintf("%s_%v", key.Key, key.Value)
+	})
+
+	return strings.Join(nameParts, "_")
+}
+
+func (d indexDefinition) toIndexModel() mongo.IndexModel {
+	opts := options.Index().SetName(d.Name)
+
+	if d.Unique {
+		opts.SetUnique(true)
+	}
+
+	if d.Sparse {
+		opts.SetSparse(true)
+	}
+
+	if d.ExpireAfterSeconds != nil {
+		opts.SetExpireAfterSeconds(*d.ExpireAfterSeconds)
+	}
+
+	if d.PartialFilterExpression != nil {
+		opts.SetPartialFilterExpression(d.PartialFilterExpression)
+	}
+
+	return mongo.IndexModel{
+		Keys:    d.Key,
+		Options: opts,
+	}
+}
+
+// isEqual reports whether the declared index d has the same keys and options as the provided existing index.
+func (d indexDefinition) isEqual(existing indexDefinition) bool {
+	if d.Unique != existing.Unique || d.Sparse != existing.Sparse {
+		return false
+	}
+
+	if (d.ExpireAfterSeconds == nil) != (existing.ExpireAfterSeconds == nil) ||
+		lo.FromPtr(d.ExpireAfterSeconds) != lo.FromPtr(existing.ExpireAfterSeconds) {
+		return false
+	}
+
+	if !isBSONEqual(d.PartialFilterExpression, existing.PartialFilterExpression) {
+		return false
+	}
+
+	return d.getComparableKey() == existing.getComparableKey()
+}
+
+// getComparableKey returns the string representation of the index keys.
+// Text fields are represented using their weights because MongoDB stores them as _fts and _ftsx keys.
+func (d indexDefinition) getComparableKey() string {
+	keyParts := []string{}
+	textFields := lo.Map(d.Weights, func(weight bson.E, _ int) string {
+		return weight.Key
+	})
+
+	for _, key := range d.Key {
+		switch {
+		case key.Key == "_fts" || key.Key == "_ftsx":
+			continue
+		case key.Value == "text":
+			textFields = append(textFields, key.Key)
+		default:
+			keyParts = append(keyParts, fmt.Sprintf("%s:%v", key.Key, normalizeIndexOrder(key.Value)))
+		}
+	}
+
+	textFields = lo.Uniq(textFields)
+	if len(textFields) > 0 {
+		// MongoDB doesn't preserve the order of text fields.
+		sort.Strings(textFields)
+		keyParts = append(keyParts, fmt.Sprintf("text:%s", strings.Join(textFields, ",")))
+	}
+
+	return strings.Join(keyParts, ";")
+}
+
+// normalizeIndexOrder converts the numeric order of an index key to int as MongoDB can return it as int32, int64 or double.
+func normalizeIndexOrder(order interface{}) interface{} {
+	switch typedOrder := order.(type) {
+	case int32:
+		return int(typedOrder)
+	case int64:
+		return int(typedOrder)
+	case float64:
+		return int(typedOrder)
+	default:
+		return order
+	}
+}
+
+func isBSONEqual(a, b bson.D) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+
+	aBytes, aErr := bson.Marshal(a)
+	bBytes, bErr := bson.Marshal(b)
+
+	return aErr == nil && bErr == nil && bytes.Equal(aBytes, bBytes)
+}
@@ -0,0 +1,43 @@
+package mgod_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type IndexesSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func TestIndexesSuite(t *testing.T) {
+	s := new(IndexesSuite)
+	suite.Run(t, s)
+}
+
+func (s *IndexesSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *IndexesSuite) TestEnsureIndexes() {
+	type indexedEntity struct {
+		Email string `bson:"email" mgoIndex:"unique;name=email_org"`
+		OrgID string `bson:"orgId" mgoIndex:"name=email_org,order=-1"`
+		Bio   string `bson:"bio" mgoIndex:"text"`
+	}
+
+	entityMongoModel := newTestModel(s.T(), indexedEntity{}, "entityMongoModelIndexed", nil)
+
+	diff, err := entityMongoModel.DiffIndexes(context.Background())
+	s.NoError(err)
+	s.Len(diff.Missing, 3)
+
+	s.NoError(entityMongoModel.EnsureIndexes(context.Background()))
+
+	diff, err = entityMongoModel.DiffIndexes(context.Background())
+	s.NoError(err)
+	s.True(diff.IsEmpty())
+}
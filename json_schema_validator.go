@@ -0,0 +1,124 @@
+package mgod
+
+import (
+	"context"
+	goerrors "errors"
+
+	"github.com/Lyearn/mgod/errors"
+	"github.com/Lyearn/mgod/schema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// namespaceNotFoundErrCode is the MongoDB error code returned when the collection doesn't exist.
+const namespaceNotFoundErrCode = 26
+
+// ValidationLevel determines how strictly MongoDB applies the validation rules to existing documents during an update.
+type ValidationLevel string
+
+const (
+	// ValidationLevelStrict applies the validation rules to all inserts and updates.
+	ValidationLevelStrict ValidationLevel = "strict"
+	// ValidationLevelModerate applies the validation rules to inserts and to updates on existing valid documents.
+	ValidationLevelModerate ValidationLevel = "moderate"
+	// ValidationLevelOff disables the validation.
+	ValidationLevelOff ValidationLevel = "off"
+)
+
+// ValidationAction determines whether MongoDB rejects the invalid documents or only logs the violations.
+type ValidationAction string
+
+const (
+	// ValidationActionError rejects the inserts and updates which violate the validation rules.
+	ValidationActionError ValidationAction = "error"
+	// ValidationActionWarn logs the violations but allows the inserts and updates.
+	ValidationActionWarn ValidationAction = "warn"
+)
+
+// JSONSchemaValidatorOptions are the options available for the ApplyJSONSchemaValidator operation of an EntityMongoModel.
+type JSONSchemaValidatorOptions struct {
+	validationLevel  ValidationLevel
+	validationAction ValidationAction
+}
+
+// NewJSONSchemaValidatorOptions creates a new JSONSchemaValidatorOptions instance with strict validation level
+// and error validation action.
+func NewJSONSchemaValidatorOptions() *JSONSchemaValidatorOptions {
+	return &JSONSchemaValidatorOptions{
+		validationLevel:  ValidationLevelStrict,
+		validationAction: ValidationActionError,
+	}
+}
+
+// SetValidationLevel sets the validation level of the validator.
+func (o *JSONSchemaValidatorOptions) SetValidationLevel(level ValidationLevel) *JSONSchemaValidatorOptions {
+	o.validationLevel = level
+	return o
+}
+
+// SetValidationAction sets the validation action of the validator.
+func (o *JSONSchemaValidatorOptions) SetValidationAction(action ValidationAction) *JSONSchemaValidatorOptions {
+	o.validationAction = action
+	return o
+}
+
+func (m entityMongoModel[T]) GetJSONSchema() bson.D {
+	return schema.BuildJSONSchema(m.schema)
+}
+
+func (m entityMongoModel[T]) ApplyJSONSchemaValidator(ctx context.Context, opts ...*JSONSchemaValidatorOptions) (err error) {
+	defer wrapOperationError("ApplyJSONSchemaValidator", &err)
+
+	if m.isUnionType {
+		return errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: "json schema validator",
+			Got:        "union type model",
+			Expected:   "non union type model",
+		})
+	}
+
+	validatorOpts := NewJSONSchemaValidatorOptions()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		if opt.validationLevel != "" {
+			validatorOpts.validationLevel = opt.validationLevel
+		}
+
+		if opt.validationAction != "" {
+			validatorOpts.validationAction = opt.validationAction
+		}
+	}
+
+	validator := bson.D{{Key: "$jsonSchema", Value: m.GetJSONSchema()}}
+
+	collModCmd := bson.D{
+		{Key: "collMod", Value: m.collName},
+		{Key: "validator", Value: validator},
+		{Key: "validationLevel", Value: string(validatorOpts.validationLevel)},
+		{Key: "validationAction", Value: string(validatorOpts.validationAction)},
+	}
+
+	db, err := m.getDB(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = db.RunCommand(ctx, collModCmd).Err()
+
+	var cmdErr mongo.CommandError
+	if !goerrors.As(err, &cmdErr) || cmdErr.Code != namespaceNotFoundErrCode {
+		return err
+	}
+
+	// collection doesn't exist yet, so creating it with the validator.
+	createCollOpts := options.CreateCollection().
+		SetValidator(validator).
+		SetValidationLevel(string(validatorOpts.validationLevel)).
+		SetValidationAction(string(validatorOpts.validationAction))
+
+	return db.CreateCollection(ctx, m.collName, createCollOpts)
+}
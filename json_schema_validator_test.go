@@ -0,0 +1,39 @@
+package mgod_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type JSONSchemaValidatorSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func TestJSONSchemaValidatorSuite(t *testing.T) {
+	s := new(JSONSchemaValidatorSuite)
+	suite.Run(t, s)
+}
+
+func (s *JSONSchemaValidatorSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *JSONSchemaValidatorSuite) TestApplyJSONSchemaValidator() {
+	entityMongoModel := newTestModel(s.T(), testEntity{}, "entityMongoModelValidated", nil)
+
+	s.NoError(entityMongoModel.ApplyJSONSchemaValidator(context.Background()))
+	// applying again should modify the existing collection.
+	s.NoError(entityMongoModel.ApplyJSONSchemaValidator(context.Background()))
+
+	_, err := entityMongoModel.InsertOne(context.Background(), testEntity{Name: "validated"})
+	s.NoError(err)
+
+	// doc inserted without mgod should also be validated.
+	_, err = entityMongoModel.InsertOne(context.Background(), bson.D{{Key: "_id", Value: "invalid-id"}})
+	s.Error(err)
+}
@@ -0,0 +1,97 @@
+package mgod_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lyearn/mgod"
+	"github.com/Lyearn/mgod/schema/schemaopt"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MetaFieldsSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func TestMetaFieldsSuite(t *testing.T) {
+	s := new(MetaFieldsSuite)
+	suite.Run(t, s)
+}
+
+func (s *MetaFieldsSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *MetaFieldsSuite) TestCustomMetaFieldKeys() {
+	schemaOpts := schemaopt.SchemaOptions{
+		Timestamps:     true,
+		CreatedAt:      &schemaopt.TimestampOptions{Key: "created_at", Format: schemaopt.TimestampFormatEpochMillis},
+		UpdatedAt:      &schemaopt.TimestampOptions{Key: "updated_at", Format: schemaopt.TimestampFormatEpochMillis},
+		VersionKeyName: lo.ToPtr("_rev"),
+	}
+
+	entityMongoModel := newTestModel(s.T(), testEntity{}, "entityMongoModelLegacy", &schemaOpts)
+
+	entity, err := entityMongoModel.InsertOne(context.Background(), testEntity{Name: "legacy"})
+	s.NoError(err)
+
+	_, err = entityMongoModel.UpdateOne(context.Background(), bson.M{"_id": entity.ID}, bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "legacy updated"}}}})
+	s.NoError(err)
+
+	docs, err := entityMongoModel.Aggregate(context.Background(), mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.M{"name": "legacy updated"}}},
+	})
+	s.NoError(err)
+	s.Len(docs, 1)
+
+	docMap := lo.SliceToMap(docs[0], func(elem bson.E) (string, interface{}) {
+		return elem.Key, elem.Value
+	})
+	s.IsType(int64(0), docMap["created_at"])
+	s.IsType(int64(0), docMap["updated_at"])
+	s.Contains(docMap, "_rev")
+	s.NotContains(docMap, "createdAt")
+}
+
+func (s *MetaFieldsSuite) TestSchemaPerSchemaOptions() {
+	clientName, err := registerTestClient("metaFields")
+	s.NoError(err)
+	defer func() { s.NoError(mgod.DisconnectClient(context.Background(), clientName)) }()
+
+	legacySchemaOpts := schemaopt.SchemaOptions{
+		Timestamps: true,
+		CreatedAt:  &schemaopt.TimestampOptions{Key: "created_at", Format: schemaopt.TimestampFormatEpochMillis},
+		UpdatedAt:  &schemaopt.TimestampOptions{Key: "updated_at", Format: schemaopt.TimestampFormatEpochMillis},
+	}
+	legacyModel := newTestModelForClient(s.T(), clientName, testEntity{}, "schemaOptions", &legacySchemaOpts)
+
+	// model of the same entity and collection with different schema options doesn't reuse the cached schema.
+	entityMongoModel := newTestModelForClient(s.T(), clientName, testEntity{}, "schemaOptions",
+		&schemaopt.SchemaOptions{Timestamps: true})
+
+	legacyDoc, err := legacyModel.GetDocToInsert(context.Background(), testEntity{
+		ID:   primitive.NewObjectID().Hex(),
+		Name: "legacy",
+	})
+	s.NoError(err)
+
+	doc, err := entityMongoModel.GetDocToInsert(context.Background(), testEntity{
+		ID:   primitive.NewObjectID().Hex(),
+		Name: "Gopher",
+	})
+	s.NoError(err)
+
+	legacyDocMap := legacyDoc.Map()
+	s.IsType(int64(0), legacyDocMap["created_at"])
+	s.NotContains(legacyDocMap, "createdAt")
+
+	docMap := doc.Map()
+	s.IsType(primitive.DateTime(0), docMap["createdAt"])
+	s.NotContains(docMap, "created_at")
+}
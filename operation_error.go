@@ -0,0 +1,111 @@
+package mgod
+
+import (
+	goerrors "errors"
+	"fmt"
+	"regexp"
+
+	"github.com/Lyearn/mgod/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// duplicateKeyIndexRegex extracts the name of the violated index from the message of a duplicate key error
+// e.g. E11000 duplicate key error collection: mgoddb.users index: emailId_1 dup key: { emailId: "gopher@mgod.com" }.
+var duplicateKeyIndexRegex = regexp.MustCompile(`index: (\S+)`)
+
+// wrapOperationError wraps the error (if any) returned by the provided operation in an [errors.OperationError].
+// It is meant to be deferred by the operations of the model with the named error result.
+// Errors already wrapped by a nested operation are left as it is.
+func wrapOperationError(op string, err *error) {
+	if *err == nil {
+		return
+	}
+
+	var opErr *errors.OperationError
+	if goerrors.As(*err, &opErr) {
+		return
+	}
+
+	opErr = &errors.OperationError{Op: op, Err: *err}
+
+	var validationErr errors.ValidationError
+	var badRequestErr errors.BadRequestError
+
+	switch {
+	case goerrors.Is(*err, mongo.ErrNoDocuments), goerrors.Is(*err, errors.ErrNotFound):
+		opErr.Kind = errors.ErrNotFound
+	case mongo.IsDuplicateKeyError(*err):
+		opErr.Kind = errors.ErrDuplicateKey
+		opErr.Err = newDuplicateKeyError(*err)
+	case goerrors.As(*err, &validationErr):
+		opErr.Kind = errors.ErrValidation
+		opErr.Path = validationErr.Path
+		opErr.Expected = validationErr.Rule
+		if validationErr.Param != "" {
+			opErr.Expected = fmt.Sprintf("%s=%s", validationErr.Rule, validationErr.Param)
+		}
+		opErr.Got = fmt.Sprint(validationErr.Value)
+	case goerrors.Is(*err, errors.ErrVersionConflict):
+		opErr.Kind = errors.ErrVersionConflict
+	case goerrors.As(*err, &badRequestErr):
+		opErr.Expected = badRequestErr.Expected
+		opErr.Got = badRequestErr.Got
+	}
+
+	*err = opErr
+}
+
+// newDuplicateKeyError returns the [errors.DuplicateKeyError] for the provided duplicate key error of the driver.
+// The violated index and the duplicate keys are parsed from the first duplicate key error reported by the server.
+func newDuplicateKeyError(err error) *errors.DuplicateKeyError {
+	duplicateKeyErr := &errors.DuplicateKeyError{Err: err}
+
+	message, raw := getDuplicateKeyErrorDetails(err)
+
+	if matches := duplicateKeyIndexRegex.FindStringSubmatch(message); len(matches) == 2 {
+		duplicateKeyErr.Index = matches[1]
+	}
+
+	if keyValue, ok := raw.Lookup("keyValue").DocumentOK(); ok {
+		var keys bson.M
+		if bson.Unmarshal(keyValue, &keys) == nil {
+			duplicateKeyErr.Keys = keys
+		}
+	}
+
+	return duplicateKeyErr
+}
+
+// getDuplicateKeyErrorDetails returns the message and the raw server response of the first duplicate key error
+// in the provided error.
+func getDuplicateKeyErrorDetails(err error) (string, bson.Raw) {
+	isDuplicateKeyCode := func(code int) bool {
+		return code == 11000 || code == 11001 || code == 12582
+	}
+
+	var writeException mongo.WriteException
+	if goerrors.As(err, &writeException) {
+		for _, writeErr := range writeException.WriteErrors {
+			if isDuplicateKeyCode(writeErr.Code) {
+				return writeErr.Message, writeErr.Raw
+			}
+		}
+	}
+
+	var bulkWriteException mongo.BulkWriteException
+	if goerrors.As(err, &bulkWriteException) {
+		for _, writeErr := range bulkWriteException.WriteErrors {
+			if isDuplicateKeyCode(writeErr.Code) {
+				return writeErr.Message, writeErr.Raw
+			}
+		}
+	}
+
+	var cmdErr mongo.CommandError
+	if goerrors.As(err, &cmdErr) {
+		return cmdErr.Message, cmdErr.Raw
+	}
+
+	return err.Error(), nil
+}
@@ -0,0 +1,113 @@
+package mgod_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lyearn/mgod"
+	"github.com/Lyearn/mgod/errors"
+	"github.com/Lyearn/mgod/schema/schemaopt"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type OperationErrorSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func TestOperationErrorSuite(t *testing.T) {
+	s := new(OperationErrorSuite)
+	suite.Run(t, s)
+}
+
+func (s *OperationErrorSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *OperationErrorSuite) getModel(collection string, schemaOpts *schemaopt.SchemaOptions) (string, mgod.EntityMongoModel[testEntity]) {
+	clientName, err := registerTestClient("operationError")
+	s.NoError(err)
+
+	return clientName, newTestModelForClient(s.T(), clientName, testEntity{}, collection, schemaOpts)
+}
+
+func (s *OperationErrorSuite) TestInvokedOperation() {
+	clientName, entityMongoModel := s.getModel("operationErrorInvoked", nil)
+
+	// operations of a disconnected client fail without reaching the server.
+	s.NoError(mgod.DisconnectClient(context.Background(), clientName))
+
+	var opErr *errors.OperationError
+
+	_, err := entityMongoModel.Find(context.Background(), bson.M{})
+	s.ErrorAs(err, &opErr)
+	s.Equal("Find", opErr.Op)
+
+	_, err = entityMongoModel.FindCursor(context.Background(), bson.M{})
+	s.ErrorAs(err, &opErr)
+	s.Equal("FindCursor", opErr.Op)
+
+	err = entityMongoModel.EnsureIndexes(context.Background())
+	s.ErrorAs(err, &opErr)
+	s.Equal("EnsureIndexes", opErr.Op)
+}
+
+func (s *OperationErrorSuite) TestBadRequest() {
+	clientName, entityMongoModel := s.getModel("operationErrorBadRequest", &schemaopt.SchemaOptions{SoftDelete: true})
+	defer func() { s.NoError(mgod.DisconnectClient(context.Background(), clientName)) }()
+
+	_, err := entityMongoModel.Aggregate(context.Background(), "pipeline")
+
+	var opErr *errors.OperationError
+	s.ErrorAs(err, &opErr)
+	s.Equal("Aggregate", opErr.Op)
+	s.Empty(opErr.Path)
+	s.Equal("mongo.Pipeline, []bson.D or bson.A", opErr.Expected)
+	s.Equal("string", opErr.Got)
+
+	var badRequestErr errors.BadRequestError
+	s.ErrorAs(err, &badRequestErr)
+	s.Equal("aggregation pipeline", badRequestErr.Underlying)
+
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "Gopher"}}}}
+
+	_, err = entityMongoModel.UpdateOneWithVersion(context.Background(), bson.M{}, update, 1)
+	s.ErrorAs(err, &opErr)
+	s.Equal("UpdateOneWithVersion", opErr.Op)
+	s.ErrorAs(err, &badRequestErr)
+	s.Equal("expected version", badRequestErr.Underlying)
+}
+
+func (s *OperationErrorSuite) TestOperationErrors() {
+	entityMongoModel := newTestEntityModel(s.T())
+	entity := insertTestEntity(s.T(), "operation-errors")
+
+	_, err := entityMongoModel.InsertOne(context.Background(), entity)
+	s.ErrorIs(err, errors.ErrDuplicateKey)
+
+	var duplicateKeyErr *errors.DuplicateKeyError
+	s.ErrorAs(err, &duplicateKeyErr)
+	s.Equal("_id_", duplicateKeyErr.Index)
+	s.Equal(entity.ID, duplicateKeyErr.Keys["_id"].(primitive.ObjectID).Hex())
+
+	var writeException mongo.WriteException
+	s.ErrorAs(err, &writeException)
+
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "operation-errors-updated"}}}}
+	_, err = entityMongoModel.FindOneAndUpdate(context.Background(), bson.M{"name": "operation-errors-missing"}, update)
+	s.ErrorIs(err, errors.ErrNotFound)
+	s.ErrorIs(err, mongo.ErrNoDocuments)
+
+	var opErr *errors.OperationError
+	s.ErrorAs(err, &opErr)
+	s.Equal("FindOneAndUpdate", opErr.Op)
+
+	// FindOne doesn't treat a missing doc as an error.
+	foundEntity, err := entityMongoModel.FindOne(context.Background(), bson.M{"name": "operation-errors-missing"})
+	s.NoError(err)
+	s.Nil(foundEntity)
+}
@@ -0,0 +1,212 @@
+package mgod
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+
+	"github.com/Lyearn/mgod/errors"
+	"github.com/Lyearn/mgod/schema/metafield"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RetryOnVersionConflict executes the provided read-modify-write function and re-executes it as long as it fails with
+// [errors.ErrVersionConflict], up to maxAttempts executions in total. The last error is returned if all the attempts fail.
+//
+// The function should always read the latest version of the doc before modifying it, so that every attempt is made
+// against the current state of the doc. maxAttempts must be at least 1.
+func RetryOnVersionConflict(ctx context.Context, maxAttempts int, fn func(ctx context.Context) error) error {
+	if maxAttempts < 1 {
+		return errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: "max attempts",
+			Got:        fmt.Sprintf("%d", maxAttempts),
+			Expected:   "at least 1",
+		})
+	}
+
+	var err error
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		err = fn(ctx)
+		if !goerrors.Is(err, errors.ErrVersionConflict) {
+			return err
+		}
+	}
+
+	return err
+}
+
+// isOptimisticConcurrencyEnabled reports whether the doc version needs to be checked for the write operations.
+func (m entityMongoModel[T]) isOptimisticConcurrencyEnabled() bool {
+	return m.schemaOpts.OptimisticConcurrency && metafield.DocVersionField.IsApplicable(m.schemaOpts)
+}
+
+// getDocVersionKey returns the key of the doc version meta field.
+func (m entityMongoModel[T]) getDocVersionKey() string {
+	return string(metafield.GetDocVersionKey(m.schemaOpts))
+}
+
+// handleDocVersionForUpdateQuery increments the doc version in the update query if optimistic concurrency is enabled.
+func (m entityMongoModel[T]) handleDocVersionForUpdateQuery(updateQuery bson.D) bson.D {
+	if !m.isOptimisticConcurrencyEnabled() {
+		return updateQuery
+	}
+
+	return addToUpdateOperator(updateQuery, "$inc", bson.E{Key: m.getDocVersionKey(), Value: 1})
+}
+
+// addDocVersionToReplaceFilter adds the doc version of the replaced model to the filter if optimistic concurrency is enabled.
+func (m entityMongoModel[T]) addDocVersionToReplaceFilter(filter, version interface{}) (interface{}, error) {
+	if !m.isOptimisticConcurrencyEnabled() {
+		return filter, nil
+	}
+
+	if version == nil {
+		return nil, errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: "replacement doc",
+			Got:        "nil",
+			Expected:   fmt.Sprintf("%s field for optimistic concurrency", m.getDocVersionKey()),
+		})
+	}
+
+	versionFilter := bson.D{{Key: m.getDocVersionKey(), Value: version}}
+	if filter == nil {
+		return versionFilter, nil
+	}
+
+	return bson.D{{Key: "$and", Value: bson.A{filter, versionFilter}}}, nil
+}
+
+// addExpectedVersionToFilter adds the expected version (if provided) to the filter of a single document update
+// operation. It also reports whether the version condition was added.
+func (m entityMongoModel[T]) addExpectedVersionToFilter(filter interface{}, expectedVersion *int) (interface{}, bool, error) {
+	if expectedVersion == nil {
+		return filter, false, nil
+	}
+
+	if !m.isOptimisticConcurrencyEnabled() {
+		return nil, false, errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: "expected version",
+			Got:        "optimistic concurrency disabled",
+			Expected:   "OptimisticConcurrency and VersionKey schema options to be enabled",
+		})
+	}
+
+	return restrictFilter(filter, bson.D{{Key: m.getDocVersionKey(), Value: *expectedVersion}}), true, nil
+}
+
+// checkDocVersionConflict is called when no doc is matched by a versioned write operation. It returns
+// [errors.ErrVersionConflict] if a doc is matched by the filter without the version condition i.e. the doc exists
+// but has been modified in the meantime.
+//
+// If the version condition was added by mgod (i.e. replace operations and update operations with an expected version),
+// the provided filter is the filter without it. Otherwise, the conflict is checked only if the user has provided the
+// loaded version at the root of the filter.
+func (m entityMongoModel[T]) checkDocVersionConflict(ctx context.Context, filter interface{}, isVersionAdded bool) error {
+	if !m.isOptimisticConcurrencyEnabled() {
+		return nil
+	}
+
+	if !isVersionAdded {
+		var found bool
+		if filter, found = removeRootField(filter, m.getDocVersionKey()); !found {
+			return nil
+		}
+	}
+
+	if filter == nil {
+		filter = bson.D{}
+	}
+
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	count, err := coll.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return errors.ErrVersionConflict
+	}
+
+	return nil
+}
+
+// handleDocVersionConflictForSingleResult converts the no documents error of a versioned single document
+// operation to [errors.ErrVersionConflict] if applicable.
+func (m entityMongoModel[T]) handleDocVersionConflictForSingleResult(
+	ctx context.Context,
+	err error,
+	filter interface{},
+	isVersionAdded bool,
+) error {
+	if !goerrors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+
+	if conflictErr := m.checkDocVersionConflict(ctx, filter, isVersionAdded); conflictErr != nil {
+		return conflictErr
+	}
+
+	return err
+}
+
+// addToUpdateOperator adds the provided fields to the operator doc of the update query.
+// Operator doc is appended to the update query if not already present.
+func addToUpdateOperator(updateQuery bson.D, operator string, fields ...bson.E) bson.D {
+	for idx, elem := range updateQuery {
+		if elem.Key != operator {
+			continue
+		}
+
+		operatorDoc, ok := elem.Value.(bson.D)
+		if !ok {
+			break
+		}
+
+		updatedOperatorDoc := append(bson.D{}, operatorDoc...)
+		updatedOperatorDoc = append(updatedOperatorDoc, fields...)
+
+		updatedQuery := append(bson.D{}, updateQuery...)
+		updatedQuery[idx].Value = updatedOperatorDoc
+
+		return updatedQuery
+	}
+
+	return append(updateQuery, bson.E{Key: operator, Value: bson.D(fields)})
+}
+
+// removeRootField returns a copy of the provided filter without the provided root level field.
+// It also reports whether the field was present in the filter.
+func removeRootField(filter interface{}, field string) (interface{}, bool) {
+	switch typedFilter := filter.(type) {
+	case bson.D:
+		updatedFilter := lo.Filter(typedFilter, func(elem bson.E, _ int) bool {
+			return elem.Key != field
+		})
+
+		return updatedFilter, len(updatedFilter) != len(typedFilter)
+	case bson.M:
+		updatedFilter, found := removeMapKey(typedFilter, field)
+		return bson.M(updatedFilter), found
+	case map[string]interface{}:
+		return removeMapKey(typedFilter, field)
+	default:
+		return filter, false
+	}
+}
+
+func removeMapKey(doc map[string]interface{}, key string) (map[string]interface{}, bool) {
+	updatedDoc := lo.OmitByKeys(doc, []string{key})
+	return updatedDoc, len(updatedDoc) != len(doc)
+}
@@ -0,0 +1,181 @@
+package mgod_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lyearn/mgod"
+	"github.com/Lyearn/mgod/errors"
+	"github.com/Lyearn/mgod/schema/schemaopt"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type OptimisticConcurrencySuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func TestOptimisticConcurrencySuite(t *testing.T) {
+	s := new(OptimisticConcurrencySuite)
+	suite.Run(t, s)
+}
+
+func (s *OptimisticConcurrencySuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *OptimisticConcurrencySuite) TestRetryOnVersionConflict() {
+	attempts := 0
+
+	err := mgod.RetryOnVersionConflict(context.Background(), 3, func(_ context.Context) error {
+		attempts++
+		if attempts < 2 {
+			return errors.ErrVersionConflict
+		}
+
+		return nil
+	})
+
+	s.NoError(err)
+	s.Equal(2, attempts)
+}
+
+func (s *OptimisticConcurrencySuite) TestRetryOnVersionConflictExhaustsAttempts() {
+	attempts := 0
+
+	err := mgod.RetryOnVersionConflict(context.Background(), 3, func(_ context.Context) error {
+		attempts++
+		return errors.ErrVersionConflict
+	})
+
+	s.ErrorIs(err, errors.ErrVersionConflict)
+	s.Equal(3, attempts)
+}
+
+func (s *OptimisticConcurrencySuite) TestRetryOnVersionConflictWithOtherError() {
+	attempts := 0
+
+	err := mgod.RetryOnVersionConflict(context.Background(), 3, func(_ context.Context) error {
+		attempts++
+		return errors.ErrSchemaNotCached
+	})
+
+	s.ErrorIs(err, errors.ErrSchemaNotCached)
+	s.Equal(1, attempts)
+}
+
+func (s *OptimisticConcurrencySuite) TestRetryOnVersionConflictWithoutAttempts() {
+	attempts := 0
+
+	err := mgod.RetryOnVersionConflict(context.Background(), 0, func(_ context.Context) error {
+		attempts++
+		return nil
+	})
+
+	var badRequestErr errors.BadRequestError
+	s.ErrorAs(err, &badRequestErr)
+	s.Equal(0, attempts)
+}
+
+func (s *OptimisticConcurrencySuite) TestReplaceOneWithVersionConflict() {
+	entityMongoModel := s.getVersionedModel()
+	entity, err := entityMongoModel.InsertOne(context.Background(), testVersionedEntity{
+		ID:   primitive.NewObjectID().Hex(),
+		Name: "versioned",
+	})
+	s.NoError(err)
+
+	staleEntity := entity
+
+	entity.Name = "versioned-updated"
+	result, err := entityMongoModel.ReplaceOne(context.Background(), bson.M{"_id": entity.ID}, entity)
+	s.NoError(err)
+	s.Equal(int64(1), result.ModifiedCount)
+
+	staleEntity.Name = "versioned-stale"
+	_, err = entityMongoModel.ReplaceOne(context.Background(), bson.M{"_id": staleEntity.ID}, staleEntity)
+	s.ErrorIs(err, errors.ErrVersionConflict)
+
+	storedEntity, err := entityMongoModel.FindOne(context.Background(), bson.M{"_id": entity.ID})
+	s.NoError(err)
+	s.Equal("versioned-updated", storedEntity.Name)
+	s.Equal(staleEntity.Version+1, storedEntity.Version)
+}
+
+func (s *OptimisticConcurrencySuite) TestUpdateOneWithVersionConflict() {
+	entityMongoModel := s.getVersionedModel()
+	entity, err := entityMongoModel.InsertOne(context.Background(), testVersionedEntity{
+		ID:   primitive.NewObjectID().Hex(),
+		Name: "versioned",
+	})
+	s.NoError(err)
+
+	filter := bson.M{"_id": entity.ID, "__v": entity.Version}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "versioned-updated"}}}}
+
+	_, err = entityMongoModel.UpdateOne(context.Background(), filter, update)
+	s.NoError(err)
+
+	_, err = entityMongoModel.UpdateOne(context.Background(), filter, update)
+	s.ErrorIs(err, errors.ErrVersionConflict)
+
+	var opErr *errors.OperationError
+	s.ErrorAs(err, &opErr)
+	s.Equal("UpdateOne", opErr.Op)
+}
+
+func (s *OptimisticConcurrencySuite) TestUpdateWithExpectedVersion() {
+	entityMongoModel := s.getVersionedModel()
+	entity, err := entityMongoModel.InsertOne(context.Background(), testVersionedEntity{
+		ID:   primitive.NewObjectID().Hex(),
+		Name: "expected-version",
+	})
+	s.NoError(err)
+
+	staleVersion := entity.Version
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "expected-version-updated"}}}}
+
+	result, err := entityMongoModel.UpdateOneWithVersion(context.Background(), bson.M{"_id": entity.ID}, update, staleVersion)
+	s.NoError(err)
+	s.Equal(int64(1), result.ModifiedCount)
+
+	_, err = entityMongoModel.UpdateOneWithVersion(context.Background(), bson.M{"_id": entity.ID}, update, staleVersion)
+	s.ErrorIs(err, errors.ErrVersionConflict)
+
+	// version is checked for a nested filter as well.
+	nestedFilter := bson.M{"$and": bson.A{bson.M{"_id": entity.ID}, bson.M{"name": "expected-version-updated"}}}
+	_, err = entityMongoModel.UpdateOneWithVersion(context.Background(), nestedFilter, update, staleVersion)
+	s.ErrorIs(err, errors.ErrVersionConflict)
+
+	_, err = entityMongoModel.FindOneAndUpdateWithVersion(context.Background(), nestedFilter, update, staleVersion)
+	s.ErrorIs(err, errors.ErrVersionConflict)
+
+	// missing doc is not a conflict.
+	missingFilter := bson.M{"_id": primitive.NewObjectID().Hex()}
+	_, err = entityMongoModel.FindOneAndUpdateWithVersion(context.Background(), missingFilter, update, staleVersion)
+	s.ErrorIs(err, errors.ErrNotFound)
+
+	// version is not checked by the plain update operations.
+	_, err = entityMongoModel.UpdateOne(context.Background(), nestedFilter, update)
+	s.NoError(err)
+
+	latestVersion := staleVersion + 2
+	updatedEntity, err := entityMongoModel.FindOneAndUpdateWithVersion(context.Background(), nestedFilter, update,
+		latestVersion, options.FindOneAndUpdate().SetReturnDocument(options.After))
+	s.NoError(err)
+	s.Equal(latestVersion+1, updatedEntity.Version)
+
+	// expected version requires optimistic concurrency.
+	_, err = newTestEntityModel(s.T()).UpdateOneWithVersion(context.Background(), bson.M{"_id": entity.ID}, update,
+		latestVersion)
+	s.Error(err)
+}
+
+func (s *OptimisticConcurrencySuite) getVersionedModel() mgod.EntityMongoModel[testVersionedEntity] {
+	schemaOpts := schemaopt.SchemaOptions{OptimisticConcurrency: true}
+	return newTestModel(s.T(), testVersionedEntity{}, "entityMongoModelVersioned", &schemaOpts)
+}
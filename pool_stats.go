@@ -0,0 +1,185 @@
+package mgod
+
+import (
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// PoolStats are the connection pool statistics of a MongoDB client (summed across the servers of the deployment).
+type PoolStats struct {
+	// OpenConnections is the number of connections created and not closed yet.
+	OpenConnections int64
+	// InUseConnections is the number of connections checked out of the pool.
+	InUseConnections int64
+	// IdleConnections is the number of open connections available in the pool.
+	IdleConnections int64
+	// PendingCheckOuts is the number of operations waiting to check out a connection.
+	PendingCheckOuts int64
+	// CheckOuts is the number of successful connection check outs.
+	CheckOuts int64
+	// CheckOutFailures is the number of failed connection check outs e.g. due to a timeout while the pool is saturated.
+	CheckOutFailures int64
+	// AvgCheckOutWait is the average time spent waiting to check out a connection.
+	AvgCheckOutWait time.Duration
+	// MaxCheckOutWait is the maximum time spent waiting to check out a connection.
+	MaxCheckOutWait time.Duration
+}
+
+var (
+	poolStatsMu sync.RWMutex
+	// poolStatsCollectors are the pool statistics collectors keyed by the client name.
+	poolStatsCollectors = map[string]*poolStatsCollector{}
+)
+
+// Stats returns the connection pool statistics of the clients keyed by the client name.
+// Statistics are available for the clients configured using ConfigureDefaultClient or ConfigureClient, and for the
+// clients created using a pool monitor returned by [NewPoolMonitor].
+func Stats() map[string]PoolStats {
+	poolStatsMu.RLock()
+	defer poolStatsMu.RUnlock()
+
+	stats := make(map[string]PoolStats, len(poolStatsCollectors))
+	for clientName, collector := range poolStatsCollectors {
+		stats[clientName] = collector.getStats()
+	}
+
+	return stats
+}
+
+// NewPoolMonitor returns a pool monitor collecting the connection pool statistics (see [Stats]) of the provided client.
+// It should be set in the client options while creating a client outside of mgod. Events are forwarded to the
+// provided next monitor (if any).
+func NewPoolMonitor(clientName string, next *event.PoolMonitor) *event.PoolMonitor {
+	collector := newPoolStatsCollector()
+	setPoolStats(clientName, collector)
+
+	return collector.poolMonitor(next)
+}
+
+// setPoolStats sets the pool statistics collector of the provided client, replacing the existing one.
+func setPoolStats(clientName string, collector *poolStatsCollector) {
+	poolStatsMu.Lock()
+	defer poolStatsMu.Unlock()
+
+	poolStatsCollectors[clientName] = collector
+}
+
+// deletePoolStats removes the pool statistics collector of the provided client.
+func deletePoolStats(clientName string) {
+	poolStatsMu.Lock()
+	defer poolStatsMu.Unlock()
+
+	delete(poolStatsCollectors, clientName)
+}
+
+// getPoolStatsCollector returns the pool statistics collector of the provided client, or nil if it is not monitored.
+func getPoolStatsCollector(clientName string) *poolStatsCollector {
+	poolStatsMu.RLock()
+	defer poolStatsMu.RUnlock()
+
+	return poolStatsCollectors[clientName]
+}
+
+// deleteReplacedPoolStats removes the provided pool statistics collector of a replaced client, unless it has been
+// replaced by the collector of the new client.
+func deleteReplacedPoolStats(clientName string, collector *poolStatsCollector) {
+	poolStatsMu.Lock()
+	defer poolStatsMu.Unlock()
+
+	if poolStatsCollectors[clientName] == collector {
+		delete(poolStatsCollectors, clientName)
+	}
+}
+
+type poolStatsCollector struct {
+	mu    sync.Mutex
+	stats PoolStats
+
+	// checkOutStartedAt holds the start time of the pending check outs per server address. Pool events don't identify
+	// the check out, so the waits are attributed in the FIFO order.
+	checkOutStartedAt map[string][]time.Time
+	totalCheckOutWait time.Duration
+}
+
+func newPoolStatsCollector() *poolStatsCollector {
+	return &poolStatsCollector{
+		checkOutStartedAt: map[string][]time.Time{},
+	}
+}
+
+// poolMonitor returns a pool monitor feeding the events to the collector before forwarding them to the provided
+// next monitor (if any).
+func (c *poolStatsCollector) poolMonitor(next *event.PoolMonitor) *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(evt *event.PoolEvent) {
+			c.handleEvent(evt)
+
+			if next != nil && next.Event != nil {
+				next.Event(evt)
+			}
+		},
+	}
+}
+
+func (c *poolStatsCollector) handleEvent(evt *event.PoolEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch evt.Type {
+	case event.ConnectionCreated:
+		c.stats.OpenConnections++
+	case event.ConnectionClosed:
+		c.stats.OpenConnections--
+	case event.GetStarted:
+		c.checkOutStartedAt[evt.Address] = append(c.checkOutStartedAt[evt.Address], time.Now())
+	case event.GetSucceeded:
+		c.stats.InUseConnections++
+		c.stats.CheckOuts++
+		c.recordCheckOutWait(evt.Address)
+	case event.GetFailed:
+		c.stats.CheckOutFailures++
+		c.recordCheckOutWait(evt.Address)
+	case event.ConnectionReturned:
+		c.stats.InUseConnections--
+	}
+}
+
+// recordCheckOutWait records the wait of the oldest pending check out of the provided server address.
+func (c *poolStatsCollector) recordCheckOutWait(address string) {
+	startedAt := c.checkOutStartedAt[address]
+	if len(startedAt) == 0 {
+		return
+	}
+
+	wait := time.Since(startedAt[0])
+	c.checkOutStartedAt[address] = startedAt[1:]
+
+	c.totalCheckOutWait += wait
+	if wait > c.stats.MaxCheckOutWait {
+		c.stats.MaxCheckOutWait = wait
+	}
+}
+
+func (c *poolStatsCollector) getStats() PoolStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+
+	stats.IdleConnections = stats.OpenConnections - stats.InUseConnections
+	if stats.IdleConnections < 0 {
+		stats.IdleConnections = 0
+	}
+
+	for _, startedAt := range c.checkOutStartedAt {
+		stats.PendingCheckOuts += int64(len(startedAt))
+	}
+
+	if waits := stats.CheckOuts + stats.CheckOutFailures; waits > 0 {
+		stats.AvgCheckOutWait = c.totalCheckOutWait / time.Duration(waits)
+	}
+
+	return stats
+}
@@ -0,0 +1,36 @@
+package mgod_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lyearn/mgod"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type PoolStatsSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func TestPoolStatsSuite(t *testing.T) {
+	s := new(PoolStatsSuite)
+	suite.Run(t, s)
+}
+
+func (s *PoolStatsSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *PoolStatsSuite) TestStats() {
+	_, err := newTestEntityModel(s.T()).CountDocuments(context.Background(), bson.M{})
+	s.NoError(err)
+
+	stats, ok := mgod.Stats()[mgod.DefaultClientName]
+	s.True(ok)
+	s.Positive(stats.OpenConnections)
+	s.Positive(stats.CheckOuts)
+	s.Equal(stats.OpenConnections-stats.InUseConnections, stats.IdleConnections)
+}
@@ -0,0 +1,311 @@
+package mgod
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Lyearn/mgod/bsondoc"
+	"github.com/Lyearn/mgod/errors"
+	"github.com/Lyearn/mgod/schema"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Populate defines a reference field to be populated in the docs returned by a query.
+type Populate struct {
+	// Path is the bson key of the root level reference field (declared using mgoRef tag) holding the _id or
+	// an array of _id of the referenced docs. Nested paths are not supported.
+	Path string
+	// Into is the name of the struct field in which the referenced docs are set. It should be of type U or *U for
+	// a single _id reference field, and of type []U or []*U for an array reference field, where U is the type of
+	// the referenced entity model. The field is usually skipped from the doc using `bson:"-"`.
+	Into string
+	// Model is the EntityMongoModel[U] of the referenced collection.
+	Model interface{}
+}
+
+// referencedModel is implemented by every EntityMongoModel to be used as a referenced model in [Populate].
+type referencedModel interface {
+	getCollectionName() string
+	findByIDs(ctx context.Context, ids bson.A) (map[string]reflect.Value, error)
+}
+
+func (m entityMongoModel[T]) getCollectionName() string {
+	return m.collName
+}
+
+// findByIDs returns the docs with the provided _id values (entity model representation) keyed by their _id.
+func (m entityMongoModel[T]) findByIDs(ctx context.Context, ids bson.A) (map[string]reflect.Value, error) {
+	// referenced docs are not populated further.
+	ctx = WithQueryOptions(ctx, QueryOptions{})
+
+	models, err := m.find(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}})
+	if err != nil {
+		return nil, err
+	}
+
+	modelsByID := make(map[string]reflect.Value, len(models))
+
+	for _, model := range models {
+		bsonDoc, err := marshalEntityModel(model)
+		if err != nil {
+			return nil, err
+		}
+
+		id := bsondoc.GetFieldValueFromRootDoc(&bsonDoc, "_id")
+		modelsByID[fmt.Sprintf("%v", id)] = reflect.ValueOf(model)
+	}
+
+	return modelsByID, nil
+}
+
+// populateRef is a populate option resolved against the schema of the model.
+type populateRef struct {
+	opt        Populate
+	refKey     string
+	isArrayRef bool
+	// queryKey identifies the query fetching the referenced docs of the option.
+	queryKey string
+	// idsByModel are the referenced _id values held by every model.
+	idsByModel [][]string
+}
+
+// populateQuery fetches the referenced docs of all the populate options having the same referenced model.
+type populateQuery struct {
+	refModel referencedModel
+	ids      []string
+}
+
+// populate sets the referenced docs in the provided models for the populate options present in the context.
+// Referenced _id values of all the options and models are grouped by the referenced model, so that a single query
+// is executed per referenced collection.
+func (m entityMongoModel[T]) populate(ctx context.Context, models []T) error {
+	populateOpts := getQueryOptions(ctx).Populate
+	if len(populateOpts) == 0 || len(models) == 0 {
+		return nil
+	}
+
+	modelVals := make([]reflect.Value, 0, len(models))
+	for idx := range models {
+		modelVal := reflect.ValueOf(&models[idx]).Elem()
+		if modelVal.Kind() != reflect.Struct {
+			return errors.NewBadRequestError(errors.BadRequestError{
+				Underlying: "populate",
+				Got:        fmt.Sprintf("%v model", modelVal.Kind()),
+				Expected:   "struct model",
+			})
+		}
+
+		modelVals = append(modelVals, modelVal)
+	}
+
+	refs := make([]populateRef, 0, len(populateOpts))
+	queries := map[string]*populateQuery{}
+	queryKeys := []string{}
+
+	for _, populateOpt := range populateOpts {
+		ref, refModel, err := m.getPopulateRef(modelVals, populateOpt)
+		if err != nil {
+			return err
+		}
+
+		query, ok := queries[ref.queryKey]
+		if !ok {
+			query = &populateQuery{refModel: refModel}
+			queries[ref.queryKey] = query
+			queryKeys = append(queryKeys, ref.queryKey)
+		}
+
+		query.ids = append(query.ids, lo.Flatten(ref.idsByModel)...)
+		refs = append(refs, ref)
+	}
+
+	refModelsByQuery := make(map[string]map[string]reflect.Value, len(queries))
+
+	for _, queryKey := range queryKeys {
+		query := queries[queryKey]
+
+		ids := lo.Uniq(query.ids)
+		if len(ids) == 0 {
+			continue
+		}
+
+		refModelsByID, err := query.refModel.findByIDs(ctx, lo.ToAnySlice(ids))
+		if err != nil {
+			return err
+		}
+
+		refModelsByQuery[queryKey] = refModelsByID
+	}
+
+	for _, ref := range refs {
+		if err := setPopulatedRef(modelVals, ref, refModelsByQuery[ref.queryKey]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getPopulateRef resolves the provided populate option against the schema of the model and collects the referenced
+// _id values of the provided models. Only root level reference fields can be populated.
+func (m entityMongoModel[T]) getPopulateRef(
+	modelVals []reflect.Value,
+	populateOpt Populate,
+) (populateRef, referencedModel, error) {
+	if strings.Contains(populateOpt.Path, ".") {
+		return populateRef{}, nil, errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: "populate path",
+			Got:        populateOpt.Path,
+			Expected:   "root level reference field",
+		})
+	}
+
+	refNode, ok := m.schema.Nodes[schema.GetPathForField(populateOpt.Path, m.schema.Root.Path)]
+	if !ok || refNode.Props.Options.Ref == "" {
+		return populateRef{}, nil, errors.NewNotFoundError(errors.NotFoundError{
+			Underlying: "populate",
+			Value:      fmt.Sprintf("reference field at path - %s", populateOpt.Path),
+		})
+	}
+
+	refModel, ok := populateOpt.Model.(referencedModel)
+	if !ok || refModel.getCollectionName() != refNode.Props.Options.Ref {
+		return populateRef{}, nil, errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: fmt.Sprintf("populate model for path - %s", populateOpt.Path),
+			Got:        fmt.Sprintf("%T", populateOpt.Model),
+			Expected:   fmt.Sprintf("EntityMongoModel of %s collection", refNode.Props.Options.Ref),
+		})
+	}
+
+	ref := populateRef{
+		opt:        populateOpt,
+		refKey:     refNode.Key,
+		isArrayRef: refNode.Props.Type == reflect.Slice,
+		queryKey:   fmt.Sprintf("%s_%T", refModel.getCollectionName(), refModel),
+		idsByModel: make([][]string, len(modelVals)),
+	}
+
+	for idx, modelVal := range modelVals {
+		ref.idsByModel[idx] = getRefIDs(modelVal.FieldByName(ref.refKey))
+	}
+
+	return ref, refModel, nil
+}
+
+// setPopulatedRef sets the referenced docs of the provided populate option in every model.
+func setPopulatedRef(modelVals []reflect.Value, ref populateRef, refModelsByID map[string]reflect.Value) error {
+	for idx, modelVal := range modelVals {
+		intoField := modelVal.FieldByName(ref.opt.Into)
+		if !intoField.IsValid() || !intoField.CanSet() {
+			return errors.NewNotFoundError(errors.NotFoundError{
+				Underlying: "populate",
+				Value:      fmt.Sprintf("settable struct field %s", ref.opt.Into),
+			})
+		}
+
+		refModels := lo.FilterMap(ref.idsByModel[idx], func(id string, _ int) (reflect.Value, bool) {
+			refModel, found := refModelsByID[id]
+			return refModel, found
+		})
+
+		if err := setPopulatedField(intoField, refModels, ref.isArrayRef); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getRefIDs returns the _id values held by a reference field of type string, *string, []string or []*string.
+func getRefIDs(refField reflect.Value) []string {
+	ids := []string{}
+
+	if !refField.IsValid() {
+		return ids
+	}
+
+	//nolint:exhaustive // reference fields can only be strings or arrays of strings.
+	switch refField.Kind() {
+	case reflect.Ptr:
+		if !refField.IsNil() {
+			ids = append(ids, getRefIDs(refField.Elem())...)
+		}
+	case reflect.String:
+		if refField.String() != "" {
+			ids = append(ids, refField.String())
+		}
+	case reflect.Slice:
+		for idx := 0; idx < refField.Len(); idx++ {
+			ids = append(ids, getRefIDs(refField.Index(idx))...)
+		}
+	}
+
+	return ids
+}
+
+// setPopulatedField sets the provided referenced models in the target field.
+func setPopulatedField(intoField reflect.Value, refModels []reflect.Value, isArrayRef bool) error {
+	intoType := intoField.Type()
+
+	if !isArrayRef {
+		if len(refModels) == 0 {
+			intoField.Set(reflect.Zero(intoType))
+			return nil
+		}
+
+		refModel, err := convertPopulatedValue(refModels[0], intoType)
+		if err != nil {
+			return err
+		}
+
+		intoField.Set(refModel)
+
+		return nil
+	}
+
+	if intoType.Kind() != reflect.Slice {
+		return newPopulateTypeMismatchError(intoType, "slice")
+	}
+
+	populatedVals := reflect.MakeSlice(intoType, 0, len(refModels))
+
+	for _, refModel := range refModels {
+		populatedVal, err := convertPopulatedValue(refModel, intoType.Elem())
+		if err != nil {
+			return err
+		}
+
+		populatedVals = reflect.Append(populatedVals, populatedVal)
+	}
+
+	intoField.Set(populatedVals)
+
+	return nil
+}
+
+// convertPopulatedValue converts the referenced model to the target type i.e. U or *U.
+func convertPopulatedValue(refModel reflect.Value, targetType reflect.Type) (reflect.Value, error) {
+	if refModel.Type().AssignableTo(targetType) {
+		return refModel, nil
+	}
+
+	if targetType.Kind() == reflect.Ptr && refModel.Type().AssignableTo(targetType.Elem()) {
+		ptr := reflect.New(targetType.Elem())
+		ptr.Elem().Set(refModel)
+
+		return ptr, nil
+	}
+
+	return reflect.Value{}, newPopulateTypeMismatchError(targetType, refModel.Type().String())
+}
+
+func newPopulateTypeMismatchError(targetType reflect.Type, expected string) error {
+	return errors.NewBadRequestError(errors.BadRequestError{
+		Underlying: "populate target field",
+		Got:        targetType.String(),
+		Expected:   expected,
+	})
+}
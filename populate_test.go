@@ -0,0 +1,81 @@
+package mgod_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lyearn/mgod"
+	"github.com/Lyearn/mgod/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type PopulateSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func TestPopulateSuite(t *testing.T) {
+	s := new(PopulateSuite)
+	suite.Run(t, s)
+}
+
+func (s *PopulateSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *PopulateSuite) TestFindWithPopulate() {
+	type populateTestPost struct {
+		ID          string       `bson:"_id" mgoType:"id"`
+		Title       string       `bson:"title"`
+		AuthorID    string       `bson:"authorId" mgoType:"id" mgoRef:"entityMongoModel"`
+		ReviewerIDs []string     `bson:"reviewerIds" mgoType:"id" mgoRef:"entityMongoModel"`
+		Author      *testEntity  `bson:"-"`
+		Reviewers   []testEntity `bson:"-"`
+	}
+
+	authorModel := newTestEntityModel(s.T())
+	author := insertTestEntity(s.T(), "populate-author")
+	reviewer := insertTestEntity(s.T(), "populate-reviewer")
+
+	postModel := newTestModel(s.T(), populateTestPost{}, "entityMongoModelPosts", nil)
+
+	post, err := postModel.InsertOne(context.Background(), populateTestPost{
+		Title:       "populate",
+		AuthorID:    author.ID,
+		ReviewerIDs: []string{reviewer.ID, author.ID},
+	})
+	s.NoError(err)
+
+	ctx := mgod.WithQueryOptions(context.Background(), mgod.QueryOptions{
+		Populate: []mgod.Populate{
+			{Path: "authorId", Into: "Author", Model: authorModel},
+			{Path: "reviewerIds", Into: "Reviewers", Model: authorModel},
+		},
+	})
+
+	populatedPost, err := postModel.FindOne(ctx, bson.M{"_id": post.ID})
+	s.NoError(err)
+	s.NotNil(populatedPost.Author)
+	s.Equal(author.Name, populatedPost.Author.Name)
+	s.Equal([]string{reviewer.Name, author.Name}, []string{populatedPost.Reviewers[0].Name, populatedPost.Reviewers[1].Name})
+
+	// populate path should be a reference field.
+	ctx = mgod.WithQueryOptions(context.Background(), mgod.QueryOptions{
+		Populate: []mgod.Populate{{Path: "title", Into: "Author", Model: authorModel}},
+	})
+	_, err = postModel.Find(ctx, bson.M{"_id": post.ID})
+	s.Error(err)
+
+	// only root level reference fields can be populated.
+	ctx = mgod.WithQueryOptions(context.Background(), mgod.QueryOptions{
+		Populate: []mgod.Populate{{Path: "author.id", Into: "Author", Model: authorModel}},
+	})
+
+	var badRequestErr errors.BadRequestError
+
+	_, err = postModel.Find(ctx, bson.M{"_id": post.ID})
+	s.ErrorAs(err, &badRequestErr)
+	s.Equal("populate path", badRequestErr.Underlying)
+}
@@ -0,0 +1,281 @@
+package mgod
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+
+	"github.com/Lyearn/mgod/bsondoc"
+	"github.com/Lyearn/mgod/errors"
+	"github.com/Lyearn/mgod/schema"
+	"github.com/Lyearn/mgod/schema/schemaopt"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindAs returns all documents in the collection of the provided model matching the provided filter as the
+// projection type P. P is a view struct declaring a subset of the fields of the entity model T (with the same bson
+// keys and field options), so only the fields of P are fetched from MongoDB and decoded.
+//
+// Projection provided in the opts is overridden by the projection derived from P. Deselected fields
+// (see [fieldopt.SelectOption]) declared in P are fetched as well. Reference fields can't be populated in P, hence
+// an error is returned if the Populate query option is provided (see [WithQueryOptions]).
+func FindAs[P any, T any](ctx context.Context, model EntityMongoModel[T], filter interface{},
+	opts ...*options.FindOptions,
+) (_ []P, err error) {
+	defer wrapOperationError("FindAs", &err)
+
+	cursor, err := findCursorAs[P](ctx, model, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []P{}
+	err = cursor.ForEach(ctx, func(result P) error {
+		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// FindCursorAs returns a cursor over all documents in the collection of the provided model matching the provided
+// filter as the projection type P. Unlike FindAs, documents are fetched and translated to P lazily while iterating
+// the cursor. See [FindAs] for more details.
+func FindCursorAs[P any, T any](ctx context.Context, model EntityMongoModel[T], filter interface{},
+	opts ...*options.FindOptions,
+) (_ EntityMongoCursor[P], err error) {
+	defer wrapOperationError("FindCursorAs", &err)
+
+	return findCursorAs[P](ctx, model, filter, opts...)
+}
+
+// findCursorAs is the implementation of FindCursorAs which doesn't wrap the returned error, so that it can be used
+// by FindAs.
+func findCursorAs[P any, T any](ctx context.Context, model EntityMongoModel[T], filter interface{},
+	opts ...*options.FindOptions,
+) (EntityMongoCursor[P], error) {
+	if err := checkProjectionQueryOptions(ctx); err != nil {
+		return nil, err
+	}
+
+	m, projectionSchema, err := getProjectionSchema[P](model)
+	if err != nil {
+		return nil, err
+	}
+
+	filterQuery, err := m.buildScopedFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = runHooks(ctx, m.hooks, beforeFindHook, filterQuery); err != nil {
+		return nil, err
+	}
+
+	projection := getProjectionFromSchema(projectionSchema)
+	opts = append(opts[:len(opts):len(opts)], options.Find().SetProjection(projection))
+
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := coll.Find(ctx, filterQuery, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newEntityMongoCursor(ctx, cursor, func(ctx context.Context, doc bson.D) (P, error) {
+		return getProjectionFromFoundDoc[P](ctx, m, projectionSchema, doc)
+	}), nil
+}
+
+// FindOneAs returns a single document from the collection of the provided model matching the provided filter as
+// the projection type P. Like FindOne, it returns nil without an error (instead of [errors.ErrNotFound]) if no document
+// matches the filter. See [FindAs] for more details.
+func FindOneAs[P any, T any](ctx context.Context, model EntityMongoModel[T], filter interface{},
+	opts ...*options.FindOneOptions,
+) (_ *P, err error) {
+	defer wrapOperationError("FindOneAs", &err)
+
+	if err = checkProjectionQueryOptions(ctx); err != nil {
+		return nil, err
+	}
+
+	m, projectionSchema, err := getProjectionSchema[P](model)
+	if err != nil {
+		return nil, err
+	}
+
+	filterQuery, err := m.buildScopedFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = runHooks(ctx, m.hooks, beforeFindHook, filterQuery); err != nil {
+		return nil, err
+	}
+
+	projection := getProjectionFromSchema(projectionSchema)
+	opts = append(opts[:len(opts):len(opts)], options.FindOne().SetProjection(projection))
+
+	var doc bson.D
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = coll.FindOne(ctx, filterQuery, opts...).Decode(&doc); err != nil {
+		if goerrors.Is(err, mongo.ErrNoDocuments) {
+			//nolint:nilnil // this is the expected behavior
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	result, err := getProjectionFromFoundDoc[P](ctx, m, projectionSchema, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// checkProjectionQueryOptions returns an error if the query options held by the provided context (see [QueryOptions])
+// are not supported by the projection queries.
+func checkProjectionQueryOptions(ctx context.Context) error {
+	if populateOpts := getQueryOptions(ctx).Populate; len(populateOpts) > 0 {
+		return errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: "projection query options",
+			Got:        fmt.Sprintf("%d populate options", len(populateOpts)),
+			Expected:   "no populate options",
+		})
+	}
+
+	return nil
+}
+
+// getProjectionSchema returns the schema of the projection type P after verifying that every field of P is
+// present in the schema of the provided entity model.
+func getProjectionSchema[P any, T any](model EntityMongoModel[T]) (*entityMongoModel[T], *schema.EntityModelSchema, error) {
+	m, ok := model.(*entityMongoModel[T])
+	if !ok {
+		return nil, nil, errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: "projection model",
+			Got:        fmt.Sprintf("%T", model),
+			Expected:   "EntityMongoModel created using NewEntityMongoModel",
+		})
+	}
+
+	var projectionType P
+	if m.isUnionType {
+		return nil, nil, errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: fmt.Sprintf("projection %T", projectionType),
+			Got:        "union type model",
+			Expected:   "non union type model",
+		})
+	}
+
+	projectionName := schema.GetSchemaNameForModel(projectionType)
+	cacheKey := GetSchemaCacheKey(m.collName, fmt.Sprintf("projection_%s", projectionName))
+
+	projectionSchema, err := schema.EntityModelSchemaCacheInstance.GetSchema(cacheKey)
+	if err != nil {
+		// meta fields are not added to the projection schema. projection should declare them to fetch them.
+		projectionSchema, err = schema.BuildSchemaForModel(projectionType, schemaopt.SchemaOptions{
+			VersionKey: lo.ToPtr(false),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		schema.EntityModelSchemaCacheInstance.SetSchema(cacheKey, projectionSchema)
+	}
+
+	trackClientSchema(m.clientName, cacheKey)
+
+	for path, node := range projectionSchema.Nodes {
+		modelNode, ok := m.schema.Nodes[path]
+		if !ok {
+			return nil, nil, errors.NewNotFoundError(errors.NotFoundError{
+				Underlying: fmt.Sprintf("projection %s", projectionName),
+				Value:      fmt.Sprintf("entity model field at path - %s", path),
+			})
+		}
+
+		if modelNode.Props.Type != node.Props.Type {
+			return nil, nil, errors.NewBadRequestError(errors.BadRequestError{
+				Underlying: fmt.Sprintf("projection %s field at path - %s", projectionName, path),
+				Got:        node.Props.Type.String(),
+				Expected:   modelNode.Props.Type.String(),
+			})
+		}
+	}
+
+	return m, projectionSchema, nil
+}
+
+// getProjectionFromSchema returns the projection including the leaf fields of the provided projection schema.
+func getProjectionFromSchema(projectionSchema *schema.EntityModelSchema) bson.D {
+	fieldPaths := []string{}
+
+	var collectFields func(nodes []schema.TreeNode)
+	collectFields = func(nodes []schema.TreeNode) {
+		for _, node := range nodes {
+			if len(node.Children) == 0 {
+				fieldPaths = append(fieldPaths, schema.GetFieldPathFromSchemaPath(node.Path))
+				continue
+			}
+
+			collectFields(node.Children)
+		}
+	}
+
+	collectFields(projectionSchema.Root.Children)
+
+	projection := bson.D{}
+	for _, fieldPath := range lo.Uniq(fieldPaths) {
+		projection = append(projection, bson.E{Key: fieldPath, Value: 1})
+	}
+
+	return projection
+}
+
+// getProjectionFromFoundDoc converts the provided bson.D doc returned by a find operation to the projection type P.
+func getProjectionFromFoundDoc[P any, T any](
+	ctx context.Context,
+	m *entityMongoModel[T],
+	projectionSchema *schema.EntityModelSchema,
+	bsonDoc bson.D,
+) (P, error) {
+	var result P
+
+	if err := runHooks(ctx, m.hooks, afterFindHook, bsonDoc); err != nil {
+		return result, err
+	}
+
+	if err := bsondoc.Build(ctx, &bsonDoc, projectionSchema, bsondoc.TranslateToEnumEntityModel); err != nil {
+		return result, err
+	}
+
+	marshalledDoc, err := bson.Marshal(bsonDoc)
+	if err != nil {
+		return result, err
+	}
+
+	if err = bson.Unmarshal(marshalledDoc, &result); err != nil {
+		return result, err
+	}
+
+	if err = runAfterFindHook(ctx, &result); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
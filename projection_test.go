@@ -0,0 +1,93 @@
+package mgod_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lyearn/mgod"
+	"github.com/Lyearn/mgod/errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type ProjectionSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func TestProjectionSuite(t *testing.T) {
+	s := new(ProjectionSuite)
+	suite.Run(t, s)
+}
+
+func (s *ProjectionSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *ProjectionSuite) TestFindAs() {
+	type testEntityName struct {
+		ID   string `bson:"_id" mgoType:"id"`
+		Name string
+	}
+
+	entityMongoModel := newTestEntityModel(s.T())
+	firstEntity := insertTestEntity(s.T(), "projection 1")
+	secondEntity := insertTestEntity(s.T(), "projection 2")
+
+	names, err := mgod.FindAs[testEntityName](context.Background(), entityMongoModel, bson.M{
+		"_id": bson.M{
+			"$in": bson.A{firstEntity.ID, secondEntity.ID},
+		},
+	})
+	s.NoError(err)
+	s.ElementsMatch([]string{"projection 1", "projection 2"}, lo.Map(names, func(name testEntityName, _ int) string {
+		return name.Name
+	}))
+
+	name, err := mgod.FindOneAs[testEntityName](context.Background(), entityMongoModel, bson.M{"_id": secondEntity.ID})
+	s.NoError(err)
+	s.Equal("projection 2", name.Name)
+
+	// every field of the projection should be present in the entity model.
+	type testEntityEmail struct {
+		Email string
+	}
+
+	_, err = mgod.FindOneAs[testEntityEmail](context.Background(), entityMongoModel, bson.M{})
+	s.Error(err)
+
+	cursor, err := mgod.FindCursorAs[testEntityName](context.Background(), entityMongoModel, bson.M{"_id": firstEntity.ID})
+	s.NoError(err)
+	s.True(cursor.Next(context.Background()))
+
+	cursorName, err := cursor.Decode()
+	s.NoError(err)
+	s.Equal("projection 1", cursorName.Name)
+	s.False(cursor.Next(context.Background()))
+	s.NoError(cursor.Close(context.Background()))
+}
+
+func (s *ProjectionSuite) TestFindAsWithPopulate() {
+	type testEntityName struct {
+		Name string
+	}
+
+	clientName, err := registerTestClient("projection")
+	s.NoError(err)
+	defer func() { s.NoError(mgod.DisconnectClient(context.Background(), clientName)) }()
+
+	entityMongoModel := newTestModelForClient(s.T(), clientName, testEntity{}, "projection", nil)
+	ctx := mgod.WithQueryOptions(context.Background(), mgod.QueryOptions{
+		Populate: []mgod.Populate{{Path: "name", Into: "Name", Model: entityMongoModel}},
+	})
+
+	var badRequestErr errors.BadRequestError
+
+	_, err = mgod.FindAs[testEntityName](ctx, entityMongoModel, bson.M{})
+	s.ErrorAs(err, &badRequestErr)
+
+	_, err = mgod.FindOneAs[testEntityName](ctx, entityMongoModel, bson.M{})
+	s.ErrorAs(err, &badRequestErr)
+}
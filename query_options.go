@@ -0,0 +1,38 @@
+package mgod
+
+import (
+	"context"
+)
+
+type queryOptionsCtxKey struct{}
+
+// QueryOptions are the mgod specific options of a query which are not available in the MongoDB driver options.
+// These options are provided using the context of the query. See [WithQueryOptions].
+type QueryOptions struct {
+	// Populate are the reference fields to be populated in the docs returned by Find and FindOne.
+	// It is not supported by FindAs, FindCursorAs and FindOneAs.
+	Populate []Populate
+
+	// Select are the dotted paths of the fields deselected using `mgoSelect:"false"` which should be included in
+	// the docs returned by Find, FindOne and FindOneAndUpdate.
+	// It has no effect if the query already provides its own projection.
+	Select []string
+
+	// WithDeleted includes the soft deleted docs in the queries of the entities with soft delete enabled.
+	WithDeleted bool
+	// OnlyDeleted restricts the queries of the entities with soft delete enabled to the soft deleted docs.
+	OnlyDeleted bool
+}
+
+// WithQueryOptions returns a copy of the provided context holding the provided query options.
+// The options apply to every query executed using the returned context, so it should be used only for the
+// intended queries.
+func WithQueryOptions(ctx context.Context, opts QueryOptions) context.Context {
+	return context.WithValue(ctx, queryOptionsCtxKey{}, opts)
+}
+
+// getQueryOptions returns the query options held by the provided context.
+func getQueryOptions(ctx context.Context) QueryOptions {
+	opts, _ := ctx.Value(queryOptionsCtxKey{}).(QueryOptions)
+	return opts
+}
@@ -0,0 +1,77 @@
+package mgod
+
+import (
+	"context"
+	goerrors "errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ResumeTokenStore persists the resume tokens of change streams so that a restarted consumer can resume
+// a change stream from where it left off.
+type ResumeTokenStore interface {
+	// GetResumeToken returns the stored resume token of the provided stream, or nil if no token is stored.
+	GetResumeToken(ctx context.Context, streamName string) (bson.Raw, error)
+
+	// SaveResumeToken stores the provided resume token of the provided stream, replacing the existing one.
+	SaveResumeToken(ctx context.Context, streamName string, resumeToken bson.Raw) error
+}
+
+type mongoResumeTokenStore struct {
+	coll *mongo.Collection
+}
+
+type resumeTokenDoc struct {
+	StreamName  string    `bson:"_id"`
+	ResumeToken bson.Raw  `bson:"resumeToken"`
+	UpdatedAt   time.Time `bson:"updatedAt"`
+}
+
+// NewMongoResumeTokenStore returns a ResumeTokenStore which stores the resume tokens in the provided MongoDB
+// collection using the default client. Every stream is stored as a separate doc with the stream name as its _id.
+func NewMongoResumeTokenStore(dbName, collection string) (ResumeTokenStore, error) {
+	return NewClientMongoResumeTokenStore(DefaultClientName, dbName, collection)
+}
+
+// NewClientMongoResumeTokenStore returns a ResumeTokenStore which stores the resume tokens in the provided MongoDB
+// collection using the provided client (registered using [RegisterClient]). See [NewMongoResumeTokenStore] for more details.
+func NewClientMongoResumeTokenStore(clientName, dbName, collection string) (ResumeTokenStore, error) {
+	dbConn, err := getDBConn(clientName, dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mongoResumeTokenStore{
+		coll: dbConn.Collection(collection),
+	}, nil
+}
+
+func (s *mongoResumeTokenStore) GetResumeToken(ctx context.Context, streamName string) (bson.Raw, error) {
+	var doc resumeTokenDoc
+
+	err := s.coll.FindOne(ctx, bson.D{{Key: "_id", Value: streamName}}).Decode(&doc)
+	if goerrors.Is(err, mongo.ErrNoDocuments) {
+		//nolint:nilnil // no token is stored for the stream yet
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return doc.ResumeToken, nil
+}
+
+func (s *mongoResumeTokenStore) SaveResumeToken(ctx context.Context, streamName string, resumeToken bson.Raw) error {
+	doc := resumeTokenDoc{
+		StreamName:  streamName,
+		ResumeToken: resumeToken,
+		UpdatedAt:   time.Now(),
+	}
+
+	_, err := s.coll.ReplaceOne(ctx, bson.D{{Key: "_id", Value: streamName}}, doc, options.Replace().SetUpsert(true))
+
+	return err
+}
@@ -4,6 +4,7 @@ package schema
 import (
 	"reflect"
 
+	"github.com/Lyearn/mgod/errors"
 	"github.com/Lyearn/mgod/schema/fieldopt"
 	"github.com/Lyearn/mgod/schema/metafield"
 	"github.com/Lyearn/mgod/schema/schemaopt"
@@ -50,6 +51,16 @@ type SchemaFieldProps struct {
 
 // BuildSchemaForModel builds the schema tree for the given model.
 func BuildSchemaForModel[T any](model T, schemaOpts schemaopt.SchemaOptions) (*EntityModelSchema, error) {
+	for _, timestampOpts := range []*schemaopt.TimestampOptions{schemaOpts.CreatedAt, schemaOpts.UpdatedAt} {
+		if timestampOpts != nil && timestampOpts.Format != "" && !timestampOpts.Format.IsValid() {
+			return nil, errors.NewBadRequestError(errors.BadRequestError{
+				Underlying: "timestamp schema option",
+				Got:        string(timestampOpts.Format),
+				Expected:   "date, epoch_millis, epoch_seconds or iso_string format",
+			})
+		}
+	}
+
 	schemaTree := make([]TreeNode, 0)
 	rootNode := GetDefaultSchemaTreeRootNode()
 
@@ -281,11 +292,7 @@ func addMetaFields[T any](model T, schemaOptions schemaopt.SchemaOptions, treeRe
 
 	rootStructFields := getCurrentLevelBSONFields(v)
 
-	for _, metaField := range metafield.GetAvailableMetaFields() {
-		if !metaField.IsApplicable(schemaOptions) {
-			continue
-		}
-
+	for _, metaField := range metafield.GetApplicableMetaFields(schemaOptions) {
 		if lo.Contains(rootStructFields, string(metaField.GetKey())) {
 			// meta field is already present in the model, so no need to add it.
 			continue
@@ -14,6 +14,7 @@ import (
 type EntityModelSchemaCache interface {
 	GetSchema(schemaName string) (*EntityModelSchema, error)
 	SetSchema(schemaName string, schema *EntityModelSchema)
+	DeleteSchema(schemaName string)
 }
 
 type entityModelSchemaCache struct {
@@ -28,6 +29,9 @@ func newEntityModelSchemaCache() EntityModelSchemaCache {
 }
 
 func (c *entityModelSchemaCache) GetSchema(schemaName string) (*EntityModelSchema, error) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
 	if schema, ok := c.cache[schemaName]; ok {
 		return schema, nil
 	}
@@ -42,5 +46,12 @@ func (c *entityModelSchemaCache) SetSchema(schemaName string, schema *EntityMode
 	c.cache[schemaName] = schema
 }
 
+func (c *entityModelSchemaCache) DeleteSchema(schemaName string) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	delete(c.cache, schemaName)
+}
+
 // EntityModelSchemaCacheInstance is the singleton instance of [EntityModelSchemaCache].
 var EntityModelSchemaCacheInstance = newEntityModelSchemaCache()
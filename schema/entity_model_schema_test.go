@@ -1,13 +1,19 @@
 package schema_test
 
 import (
+	"context"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/Lyearn/mgod/errors"
 	"github.com/Lyearn/mgod/schema"
 	"github.com/Lyearn/mgod/schema/fieldopt"
+	"github.com/Lyearn/mgod/schema/metafield"
 	"github.com/Lyearn/mgod/schema/schemaopt"
 	"github.com/Lyearn/mgod/schema/transformer"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson"
@@ -351,3 +357,356 @@ func (s *EntityModelSchemaSuite) TestBuildSchemaForModel() {
 		s.Equal(tc.ValidSchemaNodesCount, len(actualSchema.Nodes))
 	}
 }
+
+func (s *EntityModelSchemaSuite) TestBuildSchemaForModelWithIndexes() {
+	type UserProject struct {
+		ProjectID string `bson:"projectId" mgoIndex:"order=-1"`
+	}
+
+	type User struct {
+		Email     string        `bson:"email" mgoIndex:"unique;name=email_org,unique"`
+		OrgID     string        `bson:"orgId" mgoIndex:"name=email_org,order=-1"`
+		Bio       string        `bson:"bio" mgoIndex:"text"`
+		ExpiresAt string        `bson:"expiresAt" mgoIndex:"ttl=3600,sparse"`
+		Age       int           `bson:"age" mgoIndex:"partial={\"age\": {\"$gt\": 18}, \"active\": true}"`
+		Projects  []UserProject `bson:"projects"`
+	}
+
+	ttl := int32(3600)
+
+	actualSchema, err := schema.BuildSchemaForModel(User{}, schemaopt.SchemaOptions{})
+	s.Nil(err)
+
+	s.Equal([]fieldopt.IndexSpec{{Order: 1, Unique: true}, {Name: "email_org", Order: 1, Unique: true}},
+		actualSchema.Nodes["$root.email"].Props.Options.Index)
+	s.Equal([]fieldopt.IndexSpec{{Name: "email_org", Order: -1}}, actualSchema.Nodes["$root.orgId"].Props.Options.Index)
+	s.Equal([]fieldopt.IndexSpec{{Order: 1, Text: true}}, actualSchema.Nodes["$root.bio"].Props.Options.Index)
+	s.Equal([]fieldopt.IndexSpec{{Order: 1, TTL: &ttl, Sparse: true}}, actualSchema.Nodes["$root.expiresAt"].Props.Options.Index)
+	s.Equal([]fieldopt.IndexSpec{{
+		Order:         1,
+		PartialFilter: bson.D{{Key: "age", Value: bson.D{{Key: "$gt", Value: int32(18)}}}, {Key: "active", Value: true}},
+	}}, actualSchema.Nodes["$root.age"].Props.Options.Index)
+	s.Equal([]fieldopt.IndexSpec{{Order: -1}}, actualSchema.Nodes["$root.projects.$.projectId"].Props.Options.Index)
+	s.Nil(actualSchema.Nodes["$root.projects"].Props.Options.Index)
+}
+
+func (s *EntityModelSchemaSuite) TestBuildSchemaForModelWithInvalidIndex() {
+	type User struct {
+		Email string `bson:"email" mgoIndex:"order=2"`
+	}
+
+	_, err := schema.BuildSchemaForModel(User{}, schemaopt.SchemaOptions{})
+	s.NotNil(err)
+}
+
+func (s *EntityModelSchemaSuite) TestGetFieldPathFromSchemaPath() {
+	s.Equal("email", schema.GetFieldPathFromSchemaPath("$root.email"))
+	s.Equal("meta.joinedOn", schema.GetFieldPathFromSchemaPath("$root.meta.joinedOn"))
+	s.Equal("projects.projectId", schema.GetFieldPathFromSchemaPath("$root.projects.$.projectId"))
+}
+
+func (s *EntityModelSchemaSuite) TestBuildJSONSchema() {
+	type UserProject struct {
+		ProjectID string `bson:"projectId" mgoType:"id"`
+	}
+
+	type User struct {
+		ID       string        `bson:"_id" mgoType:"id"`
+		Name     string        `bson:"name"`
+		Age      *int          `bson:"age,omitempty"`
+		Score    float64       `bson:"score"`
+		JoinedOn string        `bson:"joinedOn" mgoType:"date"`
+		Tags     []string      `bson:"tags"`
+		Projects []UserProject `bson:"projects" mgoID:"false"`
+		Avatar   []byte        `bson:"avatar,omitempty"`
+	}
+
+	entityModelSchema, err := schema.BuildSchemaForModel(User{}, schemaopt.SchemaOptions{Timestamps: true})
+	s.Nil(err)
+
+	expectedJSONSchema := bson.D{
+		{Key: "bsonType", Value: "object"},
+		{Key: "properties", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "bsonType", Value: "objectId"}}},
+			{Key: "name", Value: bson.D{{Key: "bsonType", Value: "string"}}},
+			{Key: "age", Value: bson.D{{Key: "bsonType", Value: bson.A{"int", "long", "null"}}}},
+			{Key: "score", Value: bson.D{{Key: "bsonType", Value: "number"}}},
+			{Key: "joinedOn", Value: bson.D{{Key: "bsonType", Value: "date"}}},
+			{Key: "tags", Value: bson.D{
+				{Key: "bsonType", Value: bson.A{"array", "null"}},
+				{Key: "items", Value: bson.D{{Key: "bsonType", Value: "string"}}},
+			}},
+			{Key: "projects", Value: bson.D{
+				{Key: "bsonType", Value: bson.A{"array", "null"}},
+				{Key: "items", Value: bson.D{
+					{Key: "bsonType", Value: "object"},
+					{Key: "properties", Value: bson.D{
+						{Key: "projectId", Value: bson.D{{Key: "bsonType", Value: "objectId"}}},
+					}},
+					{Key: "required", Value: bson.A{"projectId"}},
+				}},
+			}},
+			{Key: "avatar", Value: bson.D{{Key: "bsonType", Value: bson.A{"binData", "null"}}}},
+			{Key: "createdAt", Value: bson.D{{Key: "bsonType", Value: "date"}}},
+			{Key: "updatedAt", Value: bson.D{{Key: "bsonType", Value: "date"}}},
+			{Key: "__v", Value: bson.D{{Key: "bsonType", Value: bson.A{"int", "long"}}}},
+		}},
+		{Key: "required", Value: bson.A{"_id", "name", "score", "joinedOn", "tags", "projects"}},
+	}
+
+	s.Equal(expectedJSONSchema, schema.BuildJSONSchema(entityModelSchema))
+}
+
+func (s *EntityModelSchemaSuite) TestBuildSchemaForModelWithRefs() {
+	type Post struct {
+		AuthorID    string   `bson:"authorId" mgoType:"id" mgoRef:"users"`
+		ReviewerIDs []string `bson:"reviewerIds" mgoType:"id" mgoRef:"users"`
+	}
+
+	actualSchema, err := schema.BuildSchemaForModel(Post{}, schemaopt.SchemaOptions{})
+	s.Nil(err)
+	s.Equal("users", actualSchema.Nodes["$root.authorId"].Props.Options.Ref)
+	s.Equal("users", actualSchema.Nodes["$root.reviewerIds"].Props.Options.Ref)
+
+	type InvalidPost struct {
+		AuthorID int `bson:"authorId" mgoRef:"users"`
+	}
+
+	_, err = schema.BuildSchemaForModel(InvalidPost{}, schemaopt.SchemaOptions{})
+	s.NotNil(err)
+}
+
+func (s *EntityModelSchemaSuite) TestBuildSchemaForModelWithSelect() {
+	type User struct {
+		Name         string `bson:"name"`
+		PasswordHash string `bson:"passwordHash" mgoSelect:"false"`
+	}
+
+	actualSchema, err := schema.BuildSchemaForModel(User{}, schemaopt.SchemaOptions{})
+	s.Nil(err)
+	s.Nil(actualSchema.Nodes["$root.name"].Props.Options.Select)
+	s.Equal(lo.ToPtr(false), actualSchema.Nodes["$root.passwordHash"].Props.Options.Select)
+
+	type InvalidUser struct {
+		PasswordHash string `bson:"passwordHash" mgoSelect:"no"`
+	}
+
+	_, err = schema.BuildSchemaForModel(InvalidUser{}, schemaopt.SchemaOptions{})
+	s.NotNil(err)
+}
+
+type lowercaseTransformer struct{}
+
+func (t lowercaseTransformer) IsTransformationRequired(_ reflect.StructField) bool {
+	return false
+}
+
+func (t lowercaseTransformer) TransformForMongoDoc(value interface{}) (interface{}, error) {
+	return strings.ToLower(value.(string)), nil
+}
+
+func (t lowercaseTransformer) TransformForEntityModelDoc(value interface{}) (interface{}, error) {
+	return value, nil
+}
+
+func (t lowercaseTransformer) GetBSONType() string {
+	return "string"
+}
+
+func init() {
+	if err := transformer.Register("lowercase", lowercaseTransformer{}); err != nil {
+		panic(err)
+	}
+}
+
+func (s *EntityModelSchemaSuite) TestBuildSchemaForModelWithRegisteredTransformer() {
+	// names should be unique.
+	s.ErrorContains(transformer.Register("lowercase", lowercaseTransformer{}), "already exists")
+	s.ErrorContains(transformer.Register("id", lowercaseTransformer{}), "already exists")
+
+	type User struct {
+		Email  string   `bson:"email" mgoType:"lowercase"`
+		Emails []string `bson:"emails" mgoType:"lowercase"`
+	}
+
+	actualSchema, err := schema.BuildSchemaForModel(User{}, schemaopt.SchemaOptions{})
+	s.Nil(err)
+	s.Equal([]transformer.Transformer{lowercaseTransformer{}}, actualSchema.Nodes["$root.email"].Props.Transformers)
+	s.Equal([]transformer.Transformer{lowercaseTransformer{}}, actualSchema.Nodes["$root.emails.$"].Props.Transformers)
+}
+
+type actorCtxKey struct{}
+
+type createdByMetaField struct{}
+
+func (m createdByMetaField) GetKey() metafield.MetaFieldKey {
+	return "createdBy"
+}
+
+func (m createdByMetaField) GetReflectKind() reflect.Kind {
+	return reflect.String
+}
+
+func (m createdByMetaField) GetApplicableTransformers() []transformer.Transformer {
+	return []transformer.Transformer{}
+}
+
+func (m createdByMetaField) IsApplicable(_ schemaopt.SchemaOptions) bool {
+	return true
+}
+
+func (m createdByMetaField) CheckIfValidValue(val interface{}) bool {
+	_, ok := val.(string)
+	return ok
+}
+
+func (m createdByMetaField) FieldAlreadyPresent(_ context.Context, _ *bson.D, _ int) error {
+	return nil
+}
+
+func (m createdByMetaField) FieldPresentWithIncorrectVal(ctx context.Context, doc *bson.D, index int) error {
+	actor, err := m.getActor(ctx)
+	(*doc)[index].Value = actor
+
+	return err
+}
+
+func (m createdByMetaField) FieldNotPresent(ctx context.Context, doc *bson.D) error {
+	actor, err := m.getActor(ctx)
+	*doc = append(*doc, bson.E{Key: string(m.GetKey()), Value: actor})
+
+	return err
+}
+
+func (m createdByMetaField) GetUpdateQueryOperation(_ context.Context) (*metafield.UpdateQueryOperation, error) {
+	//nolint:nilnil // createdBy is never updated
+	return nil, nil
+}
+
+func (m createdByMetaField) getActor(ctx context.Context) (string, error) {
+	actor, ok := ctx.Value(actorCtxKey{}).(string)
+	if !ok {
+		return "", fmt.Errorf("actor not found in context")
+	}
+
+	return actor, nil
+}
+
+func init() {
+	if err := metafield.Register(createdByMetaField{}); err != nil {
+		panic(err)
+	}
+}
+
+func (s *EntityModelSchemaSuite) TestBuildSchemaForModelWithRegisteredMetaField() {
+	// keys should be unique.
+	s.ErrorContains(metafield.Register(createdByMetaField{}), "already exists")
+
+	type User struct {
+		Name string `bson:"name"`
+	}
+
+	// user-defined meta fields are added only for the entities opting in.
+	actualSchema, err := schema.BuildSchemaForModel(User{}, schemaopt.SchemaOptions{})
+	s.Nil(err)
+	s.NotContains(actualSchema.Nodes, "$root.createdBy")
+
+	schemaOpts := schemaopt.SchemaOptions{VersionKey: lo.ToPtr(false), MetaFields: []string{"createdBy"}}
+
+	actualSchema, err = schema.BuildSchemaForModel(User{}, schemaOpts)
+	s.Nil(err)
+	s.Contains(actualSchema.Nodes, "$root.createdBy")
+
+	doc := bson.D{{Key: "name", Value: "Gopher"}}
+	ctx := context.WithValue(context.Background(), actorCtxKey{}, "admin")
+	s.NoError(metafield.AddMetaFields(ctx, &doc, schemaOpts))
+	s.Equal(bson.D{{Key: "name", Value: "Gopher"}, {Key: "createdBy", Value: "admin"}}, doc)
+
+	doc = bson.D{{Key: "name", Value: "Gopher"}}
+	s.Error(metafield.AddMetaFields(context.Background(), &doc, schemaOpts))
+}
+
+func (s *EntityModelSchemaSuite) TestBuildSchemaForModelWithCustomMetaFieldKeys() {
+	type User struct {
+		Name string `bson:"name"`
+	}
+
+	schemaOpts := schemaopt.SchemaOptions{
+		Timestamps:     true,
+		CreatedAt:      &schemaopt.TimestampOptions{Key: "created_at", Format: schemaopt.TimestampFormatEpochMillis},
+		UpdatedAt:      &schemaopt.TimestampOptions{Key: "updated_at", Format: schemaopt.TimestampFormatISOString},
+		VersionKeyName: lo.ToPtr("_rev"),
+	}
+
+	actualSchema, err := schema.BuildSchemaForModel(User{}, schemaOpts)
+	s.Nil(err)
+	s.Equal(reflect.Int64, actualSchema.Nodes["$root.created_at"].Props.Type)
+	s.Equal(reflect.String, actualSchema.Nodes["$root.updated_at"].Props.Type)
+	s.Empty(actualSchema.Nodes["$root.updated_at"].Props.Transformers)
+	s.Contains(actualSchema.Nodes, "$root._rev")
+	s.NotContains(actualSchema.Nodes, "$root.createdAt")
+	s.NotContains(actualSchema.Nodes, "$root.__v")
+
+	doc := bson.D{{Key: "name", Value: "Gopher"}}
+	s.NoError(metafield.AddMetaFields(context.Background(), &doc, schemaOpts))
+	s.Equal([]string{"name", "created_at", "updated_at", "_rev"}, lo.Map(doc, func(elem bson.E, _ int) string {
+		return elem.Key
+	}))
+	s.IsType(int64(0), doc[1].Value)
+	s.IsType("", doc[2].Value)
+
+	schemaOpts.UpdatedAt.Format = "unix"
+	_, err = schema.BuildSchemaForModel(User{}, schemaOpts)
+	s.NotNil(err)
+}
+
+func (s *EntityModelSchemaSuite) TestBuildSchemaForModelWithSoftDelete() {
+	type User struct {
+		Name string `bson:"name"`
+	}
+
+	actualSchema, err := schema.BuildSchemaForModel(User{}, schemaopt.SchemaOptions{SoftDelete: true})
+	s.Nil(err)
+	s.Equal([]transformer.Transformer{transformer.DateTransformer}, actualSchema.Nodes["$root.deletedAt"].Props.Transformers)
+	s.False(actualSchema.Nodes["$root.deletedAt"].Props.Options.Required)
+
+	// deleted at is not added to the docs which are not deleted.
+	doc := bson.D{{Key: "name", Value: "Gopher"}, {Key: "deletedAt", Value: nil}}
+	s.NoError(metafield.AddMetaFields(context.Background(), &doc, schemaopt.SchemaOptions{SoftDelete: true, VersionKey: lo.ToPtr(false)}))
+	s.Equal(bson.D{{Key: "name", Value: "Gopher"}}, doc)
+}
+
+type tenantCtxKey struct{}
+
+func (s *EntityModelSchemaSuite) TestBuildSchemaForModelWithTenancy() {
+	type User struct {
+		Name string `bson:"name"`
+	}
+
+	schemaOpts := schemaopt.SchemaOptions{
+		VersionKey: lo.ToPtr(false),
+		Tenancy: &schemaopt.TenancyOptions{
+			Key: "orgId",
+			Resolver: func(ctx context.Context) (string, error) {
+				tenant, _ := ctx.Value(tenantCtxKey{}).(string)
+				return tenant, nil
+			},
+		},
+	}
+
+	actualSchema, err := schema.BuildSchemaForModel(User{}, schemaOpts)
+	s.Nil(err)
+	s.Equal(reflect.String, actualSchema.Nodes["$root.orgId"].Props.Type)
+
+	ctx := context.WithValue(context.Background(), tenantCtxKey{}, "tenant1")
+
+	doc := bson.D{{Key: "name", Value: "Gopher"}}
+	s.NoError(metafield.AddMetaFields(ctx, &doc, schemaOpts))
+	s.Equal(bson.D{{Key: "name", Value: "Gopher"}, {Key: "orgId", Value: "tenant1"}}, doc)
+
+	crossTenantDoc := bson.D{{Key: "name", Value: "Gopher"}, {Key: "orgId", Value: "tenant2"}}
+	s.ErrorIs(metafield.AddMetaFields(ctx, &crossTenantDoc, schemaOpts), errors.ErrCrossTenantWrite)
+
+	doc = bson.D{{Key: "name", Value: "Gopher"}}
+	s.ErrorIs(metafield.AddMetaFields(context.Background(), &doc, schemaOpts), errors.ErrTenantNotFound)
+}
@@ -2,8 +2,10 @@ package schema
 
 import (
 	"reflect"
+	"strings"
 
 	"github.com/Lyearn/mgod/schema/fieldopt"
+	"github.com/samber/lo"
 )
 
 // GetSchemaNameForModel returns the default schema name for the model.
@@ -38,3 +40,15 @@ func GetPathForField(field, parent string) string {
 
 	return path
 }
+
+// GetFieldPathFromSchemaPath returns the dotted field path of the doc for the provided schema tree path.
+// e.g. $root.projects.$.projectId => projects.projectId
+func GetFieldPathFromSchemaPath(path string) string {
+	rootPath := GetDefaultSchemaTreeRootNode().Path
+
+	segments := lo.Filter(strings.Split(path, "."), func(segment string, idx int) bool {
+		return !(idx == 0 && segment == rootPath) && segment != "$"
+	})
+
+	return strings.Join(segments, ".")
+}
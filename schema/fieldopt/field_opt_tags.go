@@ -7,4 +7,8 @@ const (
 	FieldOptionTagRequired FieldOptionTag = "bson"
 	FieldOptionTagXID      FieldOptionTag = "mgoID"
 	FieldOptionTagDefault  FieldOptionTag = "mgoDefault"
+	FieldOptionTagIndex    FieldOptionTag = "mgoIndex"
+	FieldOptionTagValidate FieldOptionTag = "mgoValidate"
+	FieldOptionTagRef      FieldOptionTag = "mgoRef"
+	FieldOptionTagSelect   FieldOptionTag = "mgoSelect"
 )
@@ -11,6 +11,7 @@ import (
 type FieldOption interface {
 	// GetOptName returns the name of the schema option. This name is used to identify the unique option.
 	// NOTE: Make sure to return the same name as the name of the field in [SchemaFieldOptions] struct.
+	// For the options added using [Register], it is the key of the option value in [SchemaFieldOptions.Custom].
 	GetOptName() string
 	// GetBSONTagName returns the bson tag name for the option. This is used to identify the option and its flags in the bson tag.
 	GetBSONTagName() string
@@ -34,14 +35,31 @@ type SchemaFieldOptions struct {
 	// Default is the default value for the field. [FIELD_LEVEL]
 	// Defaults to nil. Will be populated using reflect and will be of the same type as Type in SchemaFieldProps.
 	Default interface{}
-	// not implemented yet
-	Select bool
+	// Index is the list of indexes declared on the field. [FIELD_LEVEL]
+	// Defaults to nil. Fields declaring indexes with the same name are grouped into a compound index.
+	Index []IndexSpec
+	// Validate is the list of validation rules of the field. [FIELD_LEVEL]
+	// Defaults to nil. Rules are validated while building the mongo doc.
+	Validate []ValidationRule
+	// Ref is the name of the collection referenced by the field. [FIELD_LEVEL]
+	// Defaults to empty string. Referenced docs can be populated while finding the docs.
+	Ref string
+	// Select suggests whether the field is selected by default while finding the docs. [FIELD_LEVEL]
+	// Defaults to nil i.e. selected. Deselected fields are excluded using a projection unless explicitly selected.
+	Select *bool
+	// Custom holds the values of the user-defined options (see [Register]) keyed by the option name. [FIELD_LEVEL]
+	// Defaults to nil. Options without a value for the field are not present in the map.
+	Custom map[string]interface{}
 }
 
 var availableSchemaOptions = []FieldOption{
 	RequiredOption,
 	XIDOption,
 	DefaultValueOption,
+	IndexOption,
+	ValidateOption,
+	RefOption,
+	SelectOption,
 }
 
 var optNameToSchemaOptionMap = lo.KeyBy(availableSchemaOptions, func(opt FieldOption) string {
@@ -80,5 +98,29 @@ func GetSchemaOptionsForField(field reflect.StructField) (SchemaFieldOptions, er
 		}
 	}
 
+	for _, registeredOption := range GetRegisteredOptions() {
+		var value interface{}
+
+		if registeredOption.IsApplicable(field) {
+			fieldVal, err := registeredOption.GetValue(field)
+			if err != nil {
+				return options, err
+			}
+			value = fieldVal
+		} else {
+			value = registeredOption.GetDefaultValue(field)
+		}
+
+		if value == nil {
+			continue
+		}
+
+		if options.Custom == nil {
+			options.Custom = map[string]interface{}{}
+		}
+
+		options.Custom[registeredOption.GetOptName()] = value
+	}
+
 	return options, nil
 }
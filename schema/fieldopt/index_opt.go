@@ -0,0 +1,142 @@
+package fieldopt
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Lyearn/mgod/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type indexOption struct{}
+
+func newIndexOption() FieldOption {
+	return &indexOption{}
+}
+
+// IndexOption declares the indexes on a field.
+// Multiple indexes can be declared on a field separated by `;`. Properties of an index are separated by `,` -
+//   - unique, sparse, text: flags of the index.
+//   - order=-1: sort order of the field in the index. Defaults to 1.
+//   - ttl=<seconds>: expireAfterSeconds of the index.
+//   - name=<name>: name of the index. Fields declaring the same name are grouped into a compound index.
+//   - partial=<extended JSON>: partialFilterExpression of the index. Must be the last property as it consumes the rest of the value.
+//
+// Defaults to nil for all fields.
+var IndexOption = newIndexOption()
+
+// IndexSpec is an index declared on a field using the IndexOption.
+type IndexSpec struct {
+	// Name is the name of the index. Empty name means the default name generated by MongoDB.
+	Name string
+	// Order is the sort order of the field in the index (1 or -1). Ignored for text indexes.
+	Order int
+	// Text reports whether the field is indexed as text.
+	Text bool
+	// Unique reports whether the index is unique.
+	Unique bool
+	// Sparse reports whether the index is sparse.
+	Sparse bool
+	// TTL is the expireAfterSeconds of the index.
+	TTL *int32
+	// PartialFilter is the partialFilterExpression of the index.
+	PartialFilter bson.D
+}
+
+func (o indexOption) GetOptName() string {
+	return "Index"
+}
+
+func (o indexOption) GetBSONTagName() string {
+	return string(FieldOptionTagIndex)
+}
+
+func (o indexOption) IsApplicable(field reflect.StructField) bool {
+	_, ok := field.Tag.Lookup(o.GetBSONTagName())
+	return ok
+}
+
+func (o indexOption) GetDefaultValue(field reflect.StructField) interface{} {
+	return nil
+}
+
+func (o indexOption) GetValue(field reflect.StructField) (interface{}, error) {
+	tagVal := field.Tag.Get(o.GetBSONTagName())
+
+	specs := []IndexSpec{}
+
+	for _, specVal := range strings.Split(tagVal, ";") {
+		spec, err := parseIndexSpec(strings.TrimSpace(specVal))
+		if err != nil {
+			return nil, err
+		}
+
+		specs = append(specs, spec)
+	}
+
+	return specs, nil
+}
+
+func parseIndexSpec(specVal string) (IndexSpec, error) {
+	spec := IndexSpec{Order: 1}
+
+	for specVal != "" {
+		prop, rest, _ := strings.Cut(specVal, ",")
+		key, val, _ := strings.Cut(strings.TrimSpace(prop), "=")
+
+		switch key {
+		case "unique":
+			spec.Unique = true
+		case "sparse":
+			spec.Sparse = true
+		case "text":
+			spec.Text = true
+		case "name":
+			spec.Name = val
+		case "order":
+			order, err := strconv.Atoi(val)
+			if err != nil || (order != 1 && order != -1) {
+				return spec, newInvalidIndexPropError(prop, "order as 1 or -1")
+			}
+
+			spec.Order = order
+		case "ttl":
+			ttl, err := strconv.ParseInt(val, 10, 32)
+			if err != nil || ttl < 0 {
+				return spec, newInvalidIndexPropError(prop, "ttl as non negative seconds")
+			}
+
+			ttlSeconds := int32(ttl)
+			spec.TTL = &ttlSeconds
+		case "partial":
+			// partial filter can contain commas, so it consumes the rest of the spec.
+			_, filterVal, _ := strings.Cut(specVal, "=")
+
+			var partialFilter bson.D
+			if err := bson.UnmarshalExtJSON([]byte(filterVal), false, &partialFilter); err != nil {
+				return spec, newInvalidIndexPropError(prop, "partial as a valid extended JSON document")
+			}
+
+			spec.PartialFilter = partialFilter
+			rest = ""
+		case "":
+			// empty property. e.g. empty tag value for a simple index.
+		default:
+			return spec, newInvalidIndexPropError(prop, "one of unique, sparse, text, name, order, ttl or partial")
+		}
+
+		specVal = rest
+	}
+
+	return spec, nil
+}
+
+func newInvalidIndexPropError(prop, expected string) error {
+	return errors.NewBadRequestError(errors.BadRequestError{
+		Underlying: "index option",
+		Got:        fmt.Sprintf("property %s", prop),
+		Expected:   expected,
+	})
+}
@@ -0,0 +1,57 @@
+package fieldopt
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/Lyearn/mgod/errors"
+)
+
+type refOption struct{}
+
+func newRefOption() FieldOption {
+	return &refOption{}
+}
+
+// RefOption defines the collection referenced by a field holding the _id (or an array of _id) of docs of another
+// collection. Referenced docs can be populated while finding the docs.
+// This option is applicable only for string fields and arrays of string.
+// Defaults to empty string for all fields.
+var RefOption = newRefOption()
+
+func (o refOption) GetOptName() string {
+	return "Ref"
+}
+
+func (o refOption) GetBSONTagName() string {
+	return string(FieldOptionTagRef)
+}
+
+func (o refOption) IsApplicable(field reflect.StructField) bool {
+	return field.Tag.Get(o.GetBSONTagName()) != ""
+}
+
+func (o refOption) GetDefaultValue(field reflect.StructField) interface{} {
+	return ""
+}
+
+func (o refOption) GetValue(field reflect.StructField) (interface{}, error) {
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Slice {
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType.Kind() != reflect.String {
+		return nil, errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: "ref option",
+			Got:        fmt.Sprintf("%v field", field.Type),
+			Expected:   "string or array of string field",
+		})
+	}
+
+	return field.Tag.Get(o.GetBSONTagName()), nil
+}
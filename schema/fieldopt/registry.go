@@ -0,0 +1,70 @@
+package fieldopt
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Lyearn/mgod/errors"
+)
+
+// FieldOptionHook can be optionally implemented by a registered [FieldOption] to process the values of the fields
+// using the option while building the bson doc. Hooks are invoked only for the fields present in the doc.
+type FieldOptionHook interface {
+	// OnBuildMongoDoc is invoked while translating a doc to the mongo doc with the transformed value of the field.
+	// The returned value replaces the value of the field.
+	OnBuildMongoDoc(ctx context.Context, path string, optValue, fieldValue interface{}) (interface{}, error)
+	// OnBuildEntityModelDoc is invoked while translating a mongo doc to the entity model with the value of the field
+	// before it is transformed. The returned value replaces the value of the field.
+	OnBuildEntityModelDoc(ctx context.Context, path string, optValue, fieldValue interface{}) (interface{}, error)
+}
+
+var (
+	registeredOptionsMu sync.RWMutex
+	// registeredOptions are the user-defined field options in the order of registration.
+	registeredOptions = []FieldOption{}
+)
+
+// Register adds a user-defined field option. Values of the registered options are stored in
+// [SchemaFieldOptions.Custom] keyed by the option name (see [FieldOption.GetOptName]).
+// It returns an error if an option is already available with the same name or bson tag.
+// Options should be registered before building the schema of the models using them, usually in an init function.
+func Register(opt FieldOption) error {
+	if opt == nil || opt.GetOptName() == "" || opt.GetBSONTagName() == "" {
+		return errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: "field option registration",
+			Got:        "nil option or empty name or bson tag",
+			Expected:   "option with non empty name and bson tag",
+		})
+	}
+
+	registeredOptionsMu.Lock()
+	defer registeredOptionsMu.Unlock()
+
+	for _, existingOpt := range append(availableSchemaOptions, registeredOptions...) {
+		if existingOpt.GetOptName() == opt.GetOptName() {
+			return errors.NewAlreadyExistsError(errors.AlreadyExistsError{
+				Underlying: "field option registry",
+				Value:      opt.GetOptName(),
+			})
+		}
+
+		if existingOpt.GetBSONTagName() == opt.GetBSONTagName() {
+			return errors.NewAlreadyExistsError(errors.AlreadyExistsError{
+				Underlying: "field option registry",
+				Value:      opt.GetBSONTagName(),
+			})
+		}
+	}
+
+	registeredOptions = append(registeredOptions, opt)
+
+	return nil
+}
+
+// GetRegisteredOptions returns the user-defined field options in the order of registration.
+func GetRegisteredOptions() []FieldOption {
+	registeredOptionsMu.RLock()
+	defer registeredOptionsMu.RUnlock()
+
+	return append([]FieldOption{}, registeredOptions...)
+}
@@ -0,0 +1,44 @@
+package fieldopt
+
+import (
+	"reflect"
+	"strconv"
+)
+
+type selectOption struct{}
+
+func newSelectOption() FieldOption {
+	return &selectOption{}
+}
+
+// SelectOption defines if a field is selected by default while finding the docs.
+// Fields with `mgoSelect:"false"` are excluded from the find queries using a projection unless explicitly selected.
+// Defaults to nil (i.e. selected) for all fields.
+var SelectOption = newSelectOption()
+
+func (o selectOption) GetOptName() string {
+	return "Select"
+}
+
+func (o selectOption) GetBSONTagName() string {
+	return string(FieldOptionTagSelect)
+}
+
+func (o selectOption) IsApplicable(field reflect.StructField) bool {
+	return field.Tag.Get(o.GetBSONTagName()) != ""
+}
+
+func (o selectOption) GetDefaultValue(field reflect.StructField) interface{} {
+	return nil
+}
+
+func (o selectOption) GetValue(field reflect.StructField) (interface{}, error) {
+	tagVal := field.Tag.Get(o.GetBSONTagName())
+
+	isSelected, err := strconv.ParseBool(tagVal)
+	if err != nil {
+		return nil, err
+	}
+
+	return &isSelected, nil
+}
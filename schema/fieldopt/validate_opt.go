@@ -0,0 +1,280 @@
+package fieldopt
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Lyearn/mgod/errors"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type validateOption struct{}
+
+func newValidateOption() FieldOption {
+	return &validateOption{}
+}
+
+// ValidateOption declares the validation rules of a field. Rules are separated by `,` -
+//   - min=<number>, max=<number>: bounds of a numeric value.
+//   - len=<n>, minLen=<n>, maxLen=<n>: length of a string (in characters) or an array.
+//   - regex=<pattern>: pattern to be matched by a string. Must be the last rule as it consumes the rest of the value.
+//   - enum=<a|b|c>: allowed values.
+//   - email: value must be a valid email address.
+//
+// Rules other than the length rules are applied on every element in case of an array field.
+// Defaults to nil for all fields.
+var ValidateOption = newValidateOption()
+
+// ValidationRuleName is the name of a validation rule.
+type ValidationRuleName string
+
+const (
+	ValidationRuleMin    ValidationRuleName = "min"
+	ValidationRuleMax    ValidationRuleName = "max"
+	ValidationRuleLen    ValidationRuleName = "len"
+	ValidationRuleMinLen ValidationRuleName = "minLen"
+	ValidationRuleMaxLen ValidationRuleName = "maxLen"
+	ValidationRuleRegex  ValidationRuleName = "regex"
+	ValidationRuleEnum   ValidationRuleName = "enum"
+	ValidationRuleEmail  ValidationRuleName = "email"
+)
+
+// ValidationRule is a validation rule declared on a field using the ValidateOption.
+type ValidationRule struct {
+	// Name is the name of the rule.
+	Name ValidationRuleName
+	// Param is the raw parameter of the rule as provided in the tag.
+	Param string
+
+	number float64
+	length int
+	regex  *regexp.Regexp
+	enum   []string
+}
+
+func (o validateOption) GetOptName() string {
+	return "Validate"
+}
+
+func (o validateOption) GetBSONTagName() string {
+	return string(FieldOptionTagValidate)
+}
+
+func (o validateOption) IsApplicable(field reflect.StructField) bool {
+	return field.Tag.Get(o.GetBSONTagName()) != ""
+}
+
+func (o validateOption) GetDefaultValue(field reflect.StructField) interface{} {
+	return nil
+}
+
+func (o validateOption) GetValue(field reflect.StructField) (interface{}, error) {
+	tagVal := field.Tag.Get(o.GetBSONTagName())
+
+	// rules are applied on the elements in case of an array field (except length rules).
+	fieldType := field.Type
+	elemType := fieldType
+	if fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array {
+		elemType = fieldType.Elem()
+	}
+
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+
+	rules := []ValidationRule{}
+
+	for tagVal != "" {
+		ruleVal, rest, _ := strings.Cut(tagVal, ",")
+		name, param, _ := strings.Cut(strings.TrimSpace(ruleVal), "=")
+
+		if ValidationRuleName(name) == ValidationRuleRegex {
+			// regex pattern can contain commas, so it consumes the rest of the value.
+			_, param, _ = strings.Cut(tagVal, "=")
+			rest = ""
+		}
+
+		rule, err := newValidationRule(ValidationRuleName(name), param, fieldType, elemType)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+		tagVal = rest
+	}
+
+	return rules, nil
+}
+
+func newValidationRule(name ValidationRuleName, param string, fieldType, elemType reflect.Type) (ValidationRule, error) {
+	rule := ValidationRule{Name: name, Param: param}
+
+	var err error
+
+	switch name {
+	case ValidationRuleMin, ValidationRuleMax:
+		if !isNumericKind(elemType.Kind()) {
+			return rule, newInvalidValidationRuleError(rule, "numeric field")
+		}
+
+		if rule.number, err = strconv.ParseFloat(param, 64); err != nil {
+			return rule, newInvalidValidationRuleError(rule, "numeric param")
+		}
+
+	case ValidationRuleLen, ValidationRuleMinLen, ValidationRuleMaxLen:
+		kind := fieldType.Kind()
+		if kind == reflect.Ptr {
+			kind = fieldType.Elem().Kind()
+		}
+
+		if kind != reflect.String && kind != reflect.Slice && kind != reflect.Array {
+			return rule, newInvalidValidationRuleError(rule, "string or array field")
+		}
+
+		if rule.length, err = strconv.Atoi(param); err != nil || rule.length < 0 {
+			return rule, newInvalidValidationRuleError(rule, "non negative integer param")
+		}
+
+	case ValidationRuleRegex:
+		if elemType.Kind() != reflect.String {
+			return rule, newInvalidValidationRuleError(rule, "string field")
+		}
+
+		if rule.regex, err = regexp.Compile(param); err != nil {
+			return rule, newInvalidValidationRuleError(rule, "valid regex param")
+		}
+
+	case ValidationRuleEnum:
+		if param == "" {
+			return rule, newInvalidValidationRuleError(rule, "| separated values")
+		}
+
+		rule.enum = strings.Split(param, "|")
+
+	case ValidationRuleEmail:
+		if elemType.Kind() != reflect.String {
+			return rule, newInvalidValidationRuleError(rule, "string field")
+		}
+
+	default:
+		return rule, errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: "validate option",
+			Got:        fmt.Sprintf("rule %s", name),
+			Expected:   "one of min, max, len, minLen, maxLen, regex, enum or email",
+		})
+	}
+
+	return rule, nil
+}
+
+// IsLengthRule reports whether the rule validates the length of the value.
+// Length rules are applied on the array itself, whereas other rules are applied on the array elements.
+func (r ValidationRule) IsLengthRule() bool {
+	return r.Name == ValidationRuleLen || r.Name == ValidationRuleMinLen || r.Name == ValidationRuleMaxLen
+}
+
+// IsValid reports whether the provided bson value satisfies the rule.
+func (r ValidationRule) IsValid(value interface{}) bool {
+	switch r.Name {
+	case ValidationRuleMin, ValidationRuleMax:
+		number, ok := toFloat64(value)
+		if !ok {
+			return false
+		}
+
+		if r.Name == ValidationRuleMin {
+			return number >= r.number
+		}
+
+		return number <= r.number
+
+	case ValidationRuleLen, ValidationRuleMinLen, ValidationRuleMaxLen:
+		length, ok := getLength(value)
+		if !ok {
+			return false
+		}
+
+		switch r.Name {
+		case ValidationRuleMinLen:
+			return length >= r.length
+		case ValidationRuleMaxLen:
+			return length <= r.length
+		default:
+			return length == r.length
+		}
+
+	case ValidationRuleRegex:
+		str, ok := value.(string)
+		return ok && r.regex.MatchString(str)
+
+	case ValidationRuleEnum:
+		return lo.Contains(r.enum, fmt.Sprintf("%v", value))
+
+	case ValidationRuleEmail:
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+
+		address, err := mail.ParseAddress(str)
+
+		return err == nil && address.Address == str
+
+	default:
+		return false
+	}
+}
+
+func isNumericKind(kind reflect.Kind) bool {
+	//nolint:exhaustive // only numeric kinds are relevant.
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func toFloat64(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case int:
+		return float64(number), true
+	case int32:
+		return float64(number), true
+	case int64:
+		return float64(number), true
+	case float32:
+		return float64(number), true
+	case float64:
+		return number, true
+	default:
+		return 0, false
+	}
+}
+
+func getLength(value interface{}) (int, bool) {
+	switch typedValue := value.(type) {
+	case string:
+		return utf8.RuneCountInString(typedValue), true
+	case bson.A:
+		return len(typedValue), true
+	default:
+		return 0, false
+	}
+}
+
+func newInvalidValidationRuleError(rule ValidationRule, expected string) error {
+	return errors.NewBadRequestError(errors.BadRequestError{
+		Underlying: fmt.Sprintf("%s validation rule", rule.Name),
+		Got:        fmt.Sprintf("param %s", rule.Param),
+		Expected:   expected,
+	})
+}
@@ -0,0 +1,144 @@
+package schema
+
+import (
+	"reflect"
+
+	"github.com/Lyearn/mgod/schema/transformer"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// BuildJSONSchema builds the MongoDB $jsonSchema document for the provided [EntityModelSchema].
+// Schema nodes are mapped to the bson types they are stored as i.e. transformers are taken into account.
+// Required fields are added to the required list of their parent object, and fields which can hold null values
+// (pointers, slices, maps and interfaces) allow null as well.
+// Fields not present in the schema are allowed in the document.
+func BuildJSONSchema(entityModelSchema *EntityModelSchema) bson.D {
+	return buildJSONSchemaForObject(entityModelSchema.Root.Children)
+}
+
+func buildJSONSchemaForObject(children []TreeNode) bson.D {
+	properties := bson.D{}
+	required := bson.A{}
+
+	for _, child := range children {
+		properties = append(properties, bson.E{Key: child.BSONKey, Value: buildJSONSchemaForNode(child)})
+
+		if child.Props.Options.Required {
+			required = append(required, child.BSONKey)
+		}
+	}
+
+	jsonSchema := bson.D{
+		{Key: "bsonType", Value: "object"},
+		{Key: "properties", Value: properties},
+	}
+
+	if len(required) > 0 {
+		jsonSchema = append(jsonSchema, bson.E{Key: "required", Value: required})
+	}
+
+	return jsonSchema
+}
+
+func buildJSONSchemaForNode(node TreeNode) bson.D {
+	var jsonSchema bson.D
+
+	//nolint:exhaustive // other kinds are not supported by the schema builder.
+	switch node.Props.Type {
+	case reflect.Struct:
+		jsonSchema = buildJSONSchemaForObject(node.Children)
+
+	case reflect.Slice, reflect.Array:
+		elemNode, hasElemNode := lo.Find(node.Children, func(child TreeNode) bool {
+			return child.BSONKey == "$"
+		})
+
+		if hasElemNode && elemNode.Props.Type == reflect.Uint8 && len(elemNode.Props.Transformers) == 0 {
+			// byte slices are stored as binary data.
+			jsonSchema = bson.D{{Key: "bsonType", Value: "binData"}}
+			break
+		}
+
+		jsonSchema = bson.D{{Key: "bsonType", Value: "array"}}
+		if hasElemNode {
+			jsonSchema = append(jsonSchema, bson.E{Key: "items", Value: buildJSONSchemaForNode(elemNode)})
+		}
+
+	default:
+		bsonTypes := getBSONTypesForNode(node)
+		if len(bsonTypes) == 0 {
+			// any type of value is allowed.
+			return bson.D{}
+		}
+
+		jsonSchema = bson.D{{Key: "bsonType", Value: getBSONTypeValue(bsonTypes)}}
+	}
+
+	if isNullableNode(node) {
+		jsonSchema[0].Value = addNullBSONType(jsonSchema[0].Value)
+	}
+
+	return jsonSchema
+}
+
+// getBSONTypesForNode returns the bson types allowed for the provided primitive node.
+func getBSONTypesForNode(node TreeNode) []string {
+	for _, fieldTransformer := range node.Props.Transformers {
+		if bsonTypeProvider, ok := fieldTransformer.(transformer.BSONTypeProvider); ok {
+			return []string{bsonTypeProvider.GetBSONType()}
+		}
+	}
+
+	//nolint:exhaustive // complex kinds are handled separately.
+	switch node.Props.Type {
+	case reflect.String:
+		return []string{"string"}
+	case reflect.Bool:
+		return []string{"bool"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		// integers are stored as int32 or int64 depending on their size.
+		return []string{"int", "long"}
+	case reflect.Float32, reflect.Float64:
+		return []string{"number"}
+	case reflect.Map:
+		return []string{"object"}
+	default:
+		return nil
+	}
+}
+
+// isNullableNode reports whether the field represented by the provided node can hold a null value.
+func isNullableNode(node TreeNode) bool {
+	if node.Props.IsPointer {
+		return true
+	}
+
+	//nolint:exhaustive // only nil-able kinds are nullable.
+	switch node.Props.Type {
+	case reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	default:
+		return false
+	}
+}
+
+func getBSONTypeValue(bsonTypes []string) interface{} {
+	if len(bsonTypes) == 1 {
+		return bsonTypes[0]
+	}
+
+	return bson.A(lo.ToAnySlice(bsonTypes))
+}
+
+func addNullBSONType(bsonType interface{}) interface{} {
+	switch typedBSONType := bsonType.(type) {
+	case string:
+		return bson.A{typedBSONType, "null"}
+	case bson.A:
+		return append(typedBSONType, "null")
+	default:
+		return bsonType
+	}
+}
@@ -1,36 +1,40 @@
 package metafield
 
 import (
+	"context"
 	"reflect"
-	"time"
 
-	"github.com/Lyearn/mgod/dateformatter"
 	"github.com/Lyearn/mgod/schema/schemaopt"
 	"github.com/Lyearn/mgod/schema/transformer"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
-type createdAtMetaField struct{}
+type createdAtMetaField struct {
+	opts timestampOptions
+}
 
-func newCreatedAtMetaField() MetaField {
-	return &createdAtMetaField{}
+func newCreatedAtMetaField(overrides *schemaopt.TimestampOptions) MetaField {
+	return &createdAtMetaField{
+		opts: newTimestampOptions(MetaFieldKeyCreatedAt, overrides),
+	}
 }
 
 // CreatedAtField is the meta field that stores the timestamp of the document creation.
 // This field is automatically added (if not present in the input) to the schema if the [schemaopt.SchemaOptions.Timestamps] is set to true.
-// The value of this field is set to the current timestamp in ISO format.
-var CreatedAtField = newCreatedAtMetaField()
+// The value of this field is set to the current timestamp in ISO format. Key and storage format of the field can be
+// customized using [schemaopt.SchemaOptions.CreatedAt].
+var CreatedAtField = newCreatedAtMetaField(nil)
 
 func (m createdAtMetaField) GetKey() MetaFieldKey {
-	return MetaFieldKeyCreatedAt
+	return m.opts.key
 }
 
 func (m createdAtMetaField) GetReflectKind() reflect.Kind {
-	return reflect.String
+	return m.opts.getReflectKind()
 }
 
 func (m createdAtMetaField) GetApplicableTransformers() []transformer.Transformer {
-	return []transformer.Transformer{transformer.DateTransformer}
+	return m.opts.getApplicableTransformers()
 }
 
 func (m createdAtMetaField) IsApplicable(schemaOptions schemaopt.SchemaOptions) bool {
@@ -38,32 +42,45 @@ func (m createdAtMetaField) IsApplicable(schemaOptions schemaopt.SchemaOptions)
 }
 
 func (m createdAtMetaField) CheckIfValidValue(val interface{}) bool {
-	if val, ok := val.(string); ok && val != "" {
-		return true
-	}
-
-	return false
+	return m.opts.checkIfValidValue(val)
 }
 
-func (m createdAtMetaField) FieldAlreadyPresent(doc *bson.D, index int) {
+func (m createdAtMetaField) FieldAlreadyPresent(_ context.Context, doc *bson.D, index int) error {
 	// do nothing.
+	return nil
 }
 
-func (m createdAtMetaField) FieldPresentWithIncorrectVal(doc *bson.D, index int) error {
-	isoString, err := dateformatter.New(time.Now().UTC()).GetISOString()
+func (m createdAtMetaField) FieldPresentWithIncorrectVal(_ context.Context, doc *bson.D, index int) error {
+	value, err := m.opts.getCurrentValue()
 	if err != nil {
 		return err
 	}
 
-	(*doc)[index].Value = isoString
+	(*doc)[index].Value = value
 
 	return nil
 }
 
-func (m createdAtMetaField) FieldNotPresent(doc *bson.D) {
-	isoString, _ := dateformatter.New(time.Now().UTC()).GetISOString()
+func (m createdAtMetaField) FieldNotPresent(_ context.Context, doc *bson.D) error {
+	value, err := m.opts.getCurrentValue()
+	if err != nil {
+		return err
+	}
+
 	*doc = append(*doc, bson.E{
 		Key:   string(m.GetKey()),
-		Value: isoString,
+		Value: value,
 	})
+
+	return nil
+}
+
+func (m createdAtMetaField) GetUpdateQueryOperation(_ context.Context) (*UpdateQueryOperation, error) {
+	// creation timestamp is never modified.
+	//nolint:nilnil // nil operation is a valid value
+	return nil, nil
+}
+
+func (m createdAtMetaField) withSchemaOptions(schemaOptions schemaopt.SchemaOptions) MetaField {
+	return newCreatedAtMetaField(schemaOptions.CreatedAt)
 }
@@ -0,0 +1,68 @@
+package metafield
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/Lyearn/mgod/schema/schemaopt"
+	"github.com/Lyearn/mgod/schema/transformer"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type deletedAtMetaField struct{}
+
+func newDeletedAtMetaField() MetaField {
+	return &deletedAtMetaField{}
+}
+
+// DeletedAtField is the meta field that stores the timestamp of the soft deletion of the document.
+// This field is added to the schema if the [schemaopt.SchemaOptions.SoftDelete] is set to true.
+// The field is absent for the docs which are not deleted and is set using the delete operations of the model.
+var DeletedAtField = newDeletedAtMetaField()
+
+func (m deletedAtMetaField) GetKey() MetaFieldKey {
+	return MetaFieldKeyDeletedAt
+}
+
+func (m deletedAtMetaField) GetReflectKind() reflect.Kind {
+	return reflect.String
+}
+
+func (m deletedAtMetaField) GetApplicableTransformers() []transformer.Transformer {
+	return []transformer.Transformer{transformer.DateTransformer}
+}
+
+func (m deletedAtMetaField) IsApplicable(schemaOptions schemaopt.SchemaOptions) bool {
+	return schemaOptions.SoftDelete
+}
+
+func (m deletedAtMetaField) CheckIfValidValue(val interface{}) bool {
+	if val, ok := val.(string); ok && val != "" {
+		return true
+	}
+
+	return false
+}
+
+func (m deletedAtMetaField) FieldAlreadyPresent(_ context.Context, doc *bson.D, index int) error {
+	// do nothing.
+	return nil
+}
+
+func (m deletedAtMetaField) FieldPresentWithIncorrectVal(_ context.Context, doc *bson.D, index int) error {
+	// docs which are not deleted doesn't hold the field.
+	*doc = append((*doc)[:index], (*doc)[index+1:]...)
+
+	return nil
+}
+
+func (m deletedAtMetaField) FieldNotPresent(_ context.Context, doc *bson.D) error {
+	// do nothing.
+	return nil
+}
+
+func (m deletedAtMetaField) GetUpdateQueryOperation(_ context.Context) (*UpdateQueryOperation, error) {
+	// deletion timestamp is modified only by the delete operations of the model.
+	//nolint:nilnil // nil operation is a valid value
+	return nil, nil
+}
@@ -1,6 +1,7 @@
 package metafield
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/Lyearn/mgod/schema/schemaopt"
@@ -8,19 +9,25 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 )
 
-type docVersionMetaField struct{}
+type docVersionMetaField struct {
+	key MetaFieldKey
+}
+
+func newDocVersionMetaField(key *string) MetaField {
+	if key == nil || *key == "" {
+		return &docVersionMetaField{key: MetaFieldKeyDocVersion}
+	}
 
-func newDocVersionMetaField() MetaField {
-	return &docVersionMetaField{}
+	return &docVersionMetaField{key: MetaFieldKey(*key)}
 }
 
 // DocVersionField is the meta field that stores the version of the document.
 // This field is automatically added (if not present in the input) to the schema if the [schemaopt.SchemaOptions.VersionKey] is set to true.
-// This field starts with a default value of 0.
-var DocVersionField = newDocVersionMetaField()
+// This field starts with a default value of 0. Key of the field can be customized using [schemaopt.SchemaOptions.VersionKeyName].
+var DocVersionField = newDocVersionMetaField(nil)
 
 func (m docVersionMetaField) GetKey() MetaFieldKey {
-	return MetaFieldKeyDocVersion
+	return m.key
 }
 
 func (m docVersionMetaField) GetReflectKind() reflect.Kind {
@@ -41,24 +48,59 @@ func (m docVersionMetaField) GetApplicableTransformers() []transformer.Transform
 }
 
 func (m docVersionMetaField) CheckIfValidValue(val interface{}) bool {
-	_, ok := val.(int)
+	_, ok := getDocVersion(val)
 	return ok
 }
 
-func (m docVersionMetaField) FieldAlreadyPresent(doc *bson.D, index int) {
+func (m docVersionMetaField) FieldAlreadyPresent(_ context.Context, doc *bson.D, index int) error {
 	// field is already present. hence, incrementing the value.
-	(*doc)[index].Value = (*doc)[index].Value.(int) + 1
+	version, _ := getDocVersion((*doc)[index].Value)
+	(*doc)[index].Value = version + 1
+
+	return nil
 }
 
-func (m docVersionMetaField) FieldPresentWithIncorrectVal(doc *bson.D, index int) error {
+func (m docVersionMetaField) FieldPresentWithIncorrectVal(_ context.Context, doc *bson.D, index int) error {
 	(*doc)[index].Value = 0
 
 	return nil
 }
 
-func (m docVersionMetaField) FieldNotPresent(doc *bson.D) {
+func (m docVersionMetaField) FieldNotPresent(_ context.Context, doc *bson.D) error {
 	*doc = append(*doc, bson.E{
 		Key:   string(m.GetKey()),
 		Value: 0,
 	})
+
+	return nil
+}
+
+func (m docVersionMetaField) GetUpdateQueryOperation(_ context.Context) (*UpdateQueryOperation, error) {
+	// doc version is incremented in the update queries only if optimistic concurrency is enabled for the model.
+	//nolint:nilnil // nil operation is a valid value
+	return nil, nil
+}
+
+func (m docVersionMetaField) withSchemaOptions(schemaOptions schemaopt.SchemaOptions) MetaField {
+	return newDocVersionMetaField(schemaOptions.VersionKeyName)
+}
+
+// GetDocVersionKey returns the key of the doc version meta field for the provided schema options.
+func GetDocVersionKey(schemaOptions schemaopt.SchemaOptions) MetaFieldKey {
+	return newDocVersionMetaField(schemaOptions.VersionKeyName).GetKey()
+}
+
+// getDocVersion converts the provided value to int if it is a valid doc version.
+// Values read from MongoDB are decoded as int32 or int64 depending on their size.
+func getDocVersion(val interface{}) (int, bool) {
+	switch version := val.(type) {
+	case int:
+		return version, true
+	case int32:
+		return int(version), true
+	case int64:
+		return int(version), true
+	default:
+		return 0, false
+	}
 }
@@ -4,10 +4,14 @@
 package metafield
 
 import (
+	"context"
 	"reflect"
+	"sync"
 
+	"github.com/Lyearn/mgod/errors"
 	"github.com/Lyearn/mgod/schema/schemaopt"
 	"github.com/Lyearn/mgod/schema/transformer"
+	"github.com/samber/lo"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
@@ -31,35 +35,118 @@ type MetaField interface {
 
 	// FieldAlreadyPresent modifies the doc at the provided index if the field is already present in the doc
 	// and is of the expected type.
-	FieldAlreadyPresent(doc *bson.D, index int)
+	FieldAlreadyPresent(ctx context.Context, doc *bson.D, index int) error
 
 	// FieldPresentWithIncorrectVal modifies the doc at the provided index if the field is already present in the doc
 	// but is not of the expected type.
-	FieldPresentWithIncorrectVal(doc *bson.D, index int) error
+	FieldPresentWithIncorrectVal(ctx context.Context, doc *bson.D, index int) error
 
 	// FieldNotPresent appends the missing field in the doc.
-	FieldNotPresent(doc *bson.D)
+	FieldNotPresent(ctx context.Context, doc *bson.D) error
+
+	// GetUpdateQueryOperation returns the operation to be added in the update queries for the meta field.
+	// It returns nil if the meta field is not modified by the update queries.
+	GetUpdateQueryOperation(ctx context.Context) (*UpdateQueryOperation, error)
+}
+
+// UpdateQueryOperation is the update operator and the value of a meta field added in the update queries
+// e.g. {Operator: "$currentDate", Value: true} for updatedAt meta field.
+type UpdateQueryOperation struct {
+	Operator string
+	Value    interface{}
+}
+
+// configurableMetaField is implemented by the available meta fields whose key or storage format can be customized
+// using the schema options.
+type configurableMetaField interface {
+	// withSchemaOptions returns the meta field configured according to the provided schema options.
+	withSchemaOptions(schemaOptions schemaopt.SchemaOptions) MetaField
 }
 
 var availableMetaFields = []MetaField{
 	CreatedAtField,
 	UpdatedAtField,
 	DocVersionField,
+	DeletedAtField,
+	TenantField,
+}
+
+var (
+	registeredMetaFieldsMu sync.RWMutex
+	// registeredMetaFields are the user-defined meta fields in the order of registration.
+	registeredMetaFields = []MetaField{}
+)
+
+// Register adds a user-defined meta field. Registered meta fields are added to the schema of the models
+// (if applicable) and are processed in the same way as the available meta fields.
+// It returns an error if a meta field is already available with the same key.
+// Meta fields should be registered before building the schema of the models using them, usually in an init function.
+func Register(metaField MetaField) error {
+	if metaField == nil || metaField.GetKey() == "" {
+		return errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: "meta field registration",
+			Got:        "nil meta field or empty key",
+			Expected:   "meta field with non empty key",
+		})
+	}
+
+	registeredMetaFieldsMu.Lock()
+	defer registeredMetaFieldsMu.Unlock()
+
+	for _, existingMetaField := range append(availableMetaFields, registeredMetaFields...) {
+		if existingMetaField.GetKey() == metaField.GetKey() {
+			return errors.NewAlreadyExistsError(errors.AlreadyExistsError{
+				Underlying: "meta field registry",
+				Value:      string(metaField.GetKey()),
+			})
+		}
+	}
+
+	registeredMetaFields = append(registeredMetaFields, metaField)
+
+	return nil
 }
 
-// GetAvailableMetaFields returns the list of available meta fields.
+// GetAvailableMetaFields returns the list of available meta fields followed by the user-defined meta fields.
 func GetAvailableMetaFields() []MetaField {
-	return availableMetaFields
+	registeredMetaFieldsMu.RLock()
+	defer registeredMetaFieldsMu.RUnlock()
+
+	return append(append([]MetaField{}, availableMetaFields...), registeredMetaFields...)
 }
 
-// AddMetaFields adds all applicable meta fields to the bson doc based on the provided schema options.
-func AddMetaFields(bsonDoc *bson.D, schemaOptions schemaopt.SchemaOptions) error {
+// GetApplicableMetaFields returns the meta fields applicable for the provided schema options.
+// Available meta fields are configured according to the schema options e.g. custom keys of the timestamps.
+// User-defined meta fields are applicable only if their key is present in [schemaopt.SchemaOptions.MetaFields].
+func GetApplicableMetaFields(schemaOptions schemaopt.SchemaOptions) []MetaField {
+	applicableMetaFields := []MetaField{}
+
 	for _, metaField := range availableMetaFields {
-		if !metaField.IsApplicable(schemaOptions) {
-			continue
+		if configurable, ok := metaField.(configurableMetaField); ok {
+			metaField = configurable.withSchemaOptions(schemaOptions)
+		}
+
+		if metaField.IsApplicable(schemaOptions) {
+			applicableMetaFields = append(applicableMetaFields, metaField)
 		}
+	}
 
-		if err := validatedAndAddFieldValue(bsonDoc, metaField); err != nil {
+	registeredMetaFieldsMu.RLock()
+	defer registeredMetaFieldsMu.RUnlock()
+
+	for _, metaField := range registeredMetaFields {
+		if lo.Contains(schemaOptions.MetaFields, string(metaField.GetKey())) && metaField.IsApplicable(schemaOptions) {
+			applicableMetaFields = append(applicableMetaFields, metaField)
+		}
+	}
+
+	return applicableMetaFields
+}
+
+// AddMetaFields adds all applicable meta fields to the bson doc based on the provided schema options.
+func AddMetaFields(ctx context.Context, bsonDoc *bson.D, schemaOptions schemaopt.SchemaOptions) error {
+	for _, metaField := range GetApplicableMetaFields(schemaOptions) {
+		if err := validatedAndAddFieldValue(ctx, bsonDoc, metaField); err != nil {
 			return err
 		}
 	}
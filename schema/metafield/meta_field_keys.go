@@ -7,4 +7,6 @@ const (
 	MetaFieldKeyCreatedAt  MetaFieldKey = "createdAt"
 	MetaFieldKeyUpdatedAt  MetaFieldKey = "updatedAt"
 	MetaFieldKeyDocVersion MetaFieldKey = "__v"
+	MetaFieldKeyDeletedAt  MetaFieldKey = "deletedAt"
+	MetaFieldKeyTenant     MetaFieldKey = "tenantId"
 )
@@ -0,0 +1,113 @@
+package metafield
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/Lyearn/mgod/errors"
+	"github.com/Lyearn/mgod/schema/schemaopt"
+	"github.com/Lyearn/mgod/schema/transformer"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type tenantMetaField struct {
+	key     MetaFieldKey
+	tenancy schemaopt.TenancyOptions
+}
+
+func newTenantMetaField(tenancy *schemaopt.TenancyOptions) *tenantMetaField {
+	if tenancy == nil {
+		return &tenantMetaField{key: MetaFieldKeyTenant}
+	}
+
+	key := MetaFieldKeyTenant
+	if tenancy.Key != "" {
+		key = MetaFieldKey(tenancy.Key)
+	}
+
+	return &tenantMetaField{key: key, tenancy: *tenancy}
+}
+
+// TenantField is the meta field that stores the tenant of the document.
+// This field is added to the schema if the [schemaopt.SchemaOptions.Tenancy] is provided. The tenant is resolved from
+// the context while inserting the docs, and docs of any other tenant are rejected with [errors.ErrCrossTenantWrite].
+// Key of the field can be customized using [schemaopt.TenancyOptions.Key].
+var TenantField MetaField = newTenantMetaField(nil)
+
+func (m tenantMetaField) GetKey() MetaFieldKey {
+	return m.key
+}
+
+func (m tenantMetaField) GetReflectKind() reflect.Kind {
+	return reflect.String
+}
+
+func (m tenantMetaField) GetApplicableTransformers() []transformer.Transformer {
+	return []transformer.Transformer{}
+}
+
+func (m tenantMetaField) IsApplicable(schemaOptions schemaopt.SchemaOptions) bool {
+	return schemaOptions.Tenancy != nil
+}
+
+func (m tenantMetaField) CheckIfValidValue(val interface{}) bool {
+	if val, ok := val.(string); ok && val != "" {
+		return true
+	}
+
+	return false
+}
+
+func (m tenantMetaField) FieldAlreadyPresent(ctx context.Context, doc *bson.D, index int) error {
+	tenant, err := m.tenancy.ResolveTenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	if (*doc)[index].Value != tenant {
+		return fmt.Errorf("%w: doc of tenant %v in the context of tenant %s", errors.ErrCrossTenantWrite, (*doc)[index].Value, tenant)
+	}
+
+	return nil
+}
+
+func (m tenantMetaField) FieldPresentWithIncorrectVal(ctx context.Context, doc *bson.D, index int) error {
+	tenant, err := m.tenancy.ResolveTenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	(*doc)[index].Value = tenant
+
+	return nil
+}
+
+func (m tenantMetaField) FieldNotPresent(ctx context.Context, doc *bson.D) error {
+	tenant, err := m.tenancy.ResolveTenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	*doc = append(*doc, bson.E{
+		Key:   string(m.GetKey()),
+		Value: tenant,
+	})
+
+	return nil
+}
+
+func (m tenantMetaField) GetUpdateQueryOperation(_ context.Context) (*UpdateQueryOperation, error) {
+	// tenant of a doc never changes. update queries are restricted to the tenant using the filter instead.
+	//nolint:nilnil // nil operation is a valid value
+	return nil, nil
+}
+
+func (m tenantMetaField) withSchemaOptions(schemaOptions schemaopt.SchemaOptions) MetaField {
+	return newTenantMetaField(schemaOptions.Tenancy)
+}
+
+// GetTenantKey returns the key of the tenant meta field for the provided schema options.
+func GetTenantKey(schemaOptions schemaopt.SchemaOptions) MetaFieldKey {
+	return newTenantMetaField(schemaOptions.Tenancy).GetKey()
+}
@@ -0,0 +1,111 @@
+package metafield
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/Lyearn/mgod/dateformatter"
+	"github.com/Lyearn/mgod/schema/schemaopt"
+	"github.com/Lyearn/mgod/schema/transformer"
+)
+
+// timestampOptions are the key and the storage format of a timestamp meta field.
+type timestampOptions struct {
+	key    MetaFieldKey
+	format schemaopt.TimestampFormat
+}
+
+// newTimestampOptions returns the timestamp options with the provided overrides applied on the default key and format.
+func newTimestampOptions(defaultKey MetaFieldKey, overrides *schemaopt.TimestampOptions) timestampOptions {
+	opts := timestampOptions{
+		key:    defaultKey,
+		format: schemaopt.TimestampFormatDate,
+	}
+
+	if overrides == nil {
+		return opts
+	}
+
+	if overrides.Key != "" {
+		opts.key = MetaFieldKey(overrides.Key)
+	}
+
+	if overrides.Format != "" {
+		opts.format = overrides.Format
+	}
+
+	return opts
+}
+
+func (o timestampOptions) isEpochFormat() bool {
+	return o.format == schemaopt.TimestampFormatEpochMillis || o.format == schemaopt.TimestampFormatEpochSeconds
+}
+
+// getReflectKind returns the reflect kind of the field in the entity model.
+func (o timestampOptions) getReflectKind() reflect.Kind {
+	if o.isEpochFormat() {
+		return reflect.Int64
+	}
+
+	return reflect.String
+}
+
+// getApplicableTransformers returns the transformers of the field. Only BSON Date needs to be transformed as
+// the field holds the ISO string in the entity model.
+func (o timestampOptions) getApplicableTransformers() []transformer.Transformer {
+	if o.format == schemaopt.TimestampFormatDate {
+		return []transformer.Transformer{transformer.DateTransformer}
+	}
+
+	return []transformer.Transformer{}
+}
+
+// checkIfValidValue validates the type of the provided value against the storage format.
+func (o timestampOptions) checkIfValidValue(val interface{}) bool {
+	if o.isEpochFormat() {
+		switch typedVal := val.(type) {
+		case int:
+			return typedVal > 0
+		case int32:
+			return typedVal > 0
+		case int64:
+			return typedVal > 0
+		default:
+			return false
+		}
+	}
+
+	if val, ok := val.(string); ok && val != "" {
+		return true
+	}
+
+	return false
+}
+
+// getCurrentValue returns the current timestamp in the entity model representation of the storage format.
+func (o timestampOptions) getCurrentValue() (interface{}, error) {
+	now := time.Now().UTC()
+
+	switch o.format {
+	case schemaopt.TimestampFormatEpochMillis:
+		return now.UnixMilli(), nil
+	case schemaopt.TimestampFormatEpochSeconds:
+		return now.Unix(), nil
+	default:
+		return dateformatter.New(now).GetISOString()
+	}
+}
+
+// getUpdateQueryOperation returns the update query operation setting the field to the current timestamp.
+func (o timestampOptions) getUpdateQueryOperation() (*UpdateQueryOperation, error) {
+	if o.format == schemaopt.TimestampFormatDate {
+		return &UpdateQueryOperation{Operator: "$currentDate", Value: true}, nil
+	}
+
+	value, err := o.getCurrentValue()
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateQueryOperation{Operator: "$set", Value: value}, nil
+}
@@ -1,36 +1,40 @@
 package metafield
 
 import (
+	"context"
 	"reflect"
-	"time"
 
-	"github.com/Lyearn/mgod/dateformatter"
 	"github.com/Lyearn/mgod/schema/schemaopt"
 	"github.com/Lyearn/mgod/schema/transformer"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
-type updatedAtMetaField struct{}
+type updatedAtMetaField struct {
+	opts timestampOptions
+}
 
-func newUpdatedAtMetaField() MetaField {
-	return &updatedAtMetaField{}
+func newUpdatedAtMetaField(overrides *schemaopt.TimestampOptions) MetaField {
+	return &updatedAtMetaField{
+		opts: newTimestampOptions(MetaFieldKeyUpdatedAt, overrides),
+	}
 }
 
 // UpdatedAtField is the meta field that stores the timestamp of the document updation.
 // This field is automatically added (if not present in the input) to the schema if the [schemaopt.SchemaOptions.Timestamps] is set to true.
 // The value of this field is set to the current timestamp in ISO format and is updated every time the document is updated.
-var UpdatedAtField = newUpdatedAtMetaField()
+// Key and storage format of the field can be customized using [schemaopt.SchemaOptions.UpdatedAt].
+var UpdatedAtField = newUpdatedAtMetaField(nil)
 
 func (m updatedAtMetaField) GetKey() MetaFieldKey {
-	return MetaFieldKeyUpdatedAt
+	return m.opts.key
 }
 
 func (m updatedAtMetaField) GetReflectKind() reflect.Kind {
-	return reflect.String
+	return m.opts.getReflectKind()
 }
 
 func (m updatedAtMetaField) GetApplicableTransformers() []transformer.Transformer {
-	return []transformer.Transformer{transformer.DateTransformer}
+	return m.opts.getApplicableTransformers()
 }
 
 func (m updatedAtMetaField) IsApplicable(schemaOptions schemaopt.SchemaOptions) bool {
@@ -38,34 +42,50 @@ func (m updatedAtMetaField) IsApplicable(schemaOptions schemaopt.SchemaOptions)
 }
 
 func (m updatedAtMetaField) CheckIfValidValue(val interface{}) bool {
-	if val, ok := val.(string); ok && val != "" {
-		return true
-	}
-
-	return false
+	return m.opts.checkIfValidValue(val)
 }
 
-func (m updatedAtMetaField) FieldAlreadyPresent(doc *bson.D, index int) {
+func (m updatedAtMetaField) FieldAlreadyPresent(_ context.Context, doc *bson.D, index int) error {
 	// field is already present. hence, updating the value.
-	isoString, _ := dateformatter.New(time.Now().UTC()).GetISOString()
-	(*doc)[index].Value = isoString
+	value, err := m.opts.getCurrentValue()
+	if err != nil {
+		return err
+	}
+
+	(*doc)[index].Value = value
+
+	return nil
 }
 
-func (m updatedAtMetaField) FieldPresentWithIncorrectVal(doc *bson.D, index int) error {
-	isoString, err := dateformatter.New(time.Now().UTC()).GetISOString()
+func (m updatedAtMetaField) FieldPresentWithIncorrectVal(_ context.Context, doc *bson.D, index int) error {
+	value, err := m.opts.getCurrentValue()
 	if err != nil {
 		return err
 	}
 
-	(*doc)[index].Value = isoString
+	(*doc)[index].Value = value
 
 	return nil
 }
 
-func (m updatedAtMetaField) FieldNotPresent(doc *bson.D) {
-	isoString, _ := dateformatter.New(time.Now().UTC()).GetISOString()
+func (m updatedAtMetaField) FieldNotPresent(_ context.Context, doc *bson.D) error {
+	value, err := m.opts.getCurrentValue()
+	if err != nil {
+		return err
+	}
+
 	*doc = append(*doc, bson.E{
 		Key:   string(m.GetKey()),
-		Value: isoString,
+		Value: value,
 	})
+
+	return nil
+}
+
+func (m updatedAtMetaField) GetUpdateQueryOperation(_ context.Context) (*UpdateQueryOperation, error) {
+	return m.opts.getUpdateQueryOperation()
+}
+
+func (m updatedAtMetaField) withSchemaOptions(schemaOptions schemaopt.SchemaOptions) MetaField {
+	return newUpdatedAtMetaField(schemaOptions.UpdatedAt)
 }
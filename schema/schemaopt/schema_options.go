@@ -1,13 +1,96 @@
 package schemaopt
 
+import (
+	"context"
+	"fmt"
+
+	"github.com/Lyearn/mgod/errors"
+)
+
 // SchemaOptions is Mongo Schema level options (modifies actual MongoDB doc) that needs to be provided when creating a new EntityMongoModel.
 type SchemaOptions struct {
 	// Timestamps reports whether to add createdAt and updatedAt meta fields for the entity.
 	Timestamps bool
+	// CreatedAt configures the key and the storage format of the createdAt meta field. Defaults to createdAt stored as BSON Date.
+	CreatedAt *TimestampOptions
+	// UpdatedAt configures the key and the storage format of the updatedAt meta field. Defaults to updatedAt stored as BSON Date.
+	UpdatedAt *TimestampOptions
 	// VersionKey reports whether to add a version key for the entity. Defaults to true.
 	VersionKey *bool
+	// VersionKeyName is the key of the version meta field. Defaults to __v.
+	VersionKeyName *string // bson key
+	// OptimisticConcurrency reports whether to use the version key for optimistic concurrency control of the entity.
+	// If enabled, update queries increment the version key and replace queries are applied only if the version key of the
+	// provided model matches the version key of the stored doc. Requires VersionKey to be enabled.
+	OptimisticConcurrency bool
+	// SoftDelete reports whether to soft delete the docs of the entity by setting the deletedAt meta field instead of
+	// removing them. Soft deleted docs are excluded from the find queries unless requested explicitly.
+	SoftDelete bool
+	// Tenancy enables the shared collection multi-tenancy for the entity i.e. every doc holds the tenant it belongs to
+	// and all the queries of the model are restricted to the tenant resolved from the context.
+	Tenancy *TenancyOptions
 	// IsUnionType reports whether the entity is a union type.
 	IsUnionType bool
 	// DiscriminatorKey is the key used to identify the underlying type in case of a union type entity. Defaults to __t.
 	DiscriminatorKey *string // bson key
+	// MetaFields are the keys of the user-defined meta fields (registered using metafield.Register) to be added for the entity.
+	MetaFields []string
+}
+
+// TimestampFormat is the representation in which a timestamp meta field is stored in MongoDB.
+type TimestampFormat string
+
+const (
+	TimestampFormatDate         TimestampFormat = "date"          // BSON Date. ISO string in the entity model.
+	TimestampFormatEpochMillis  TimestampFormat = "epoch_millis"  // milliseconds since Unix epoch as int64.
+	TimestampFormatEpochSeconds TimestampFormat = "epoch_seconds" // seconds since Unix epoch as int64.
+	TimestampFormatISOString    TimestampFormat = "iso_string"    // ISO string.
+)
+
+// IsValid reports whether the format is one of the supported timestamp formats.
+func (f TimestampFormat) IsValid() bool {
+	switch f {
+	case TimestampFormatDate, TimestampFormatEpochMillis, TimestampFormatEpochSeconds, TimestampFormatISOString:
+		return true
+	default:
+		return false
+	}
+}
+
+// TimestampOptions are the options of a timestamp meta field.
+type TimestampOptions struct {
+	// Key is the bson key of the field. Defaults to the name of the meta field.
+	Key string
+	// Format is the storage format of the field. Defaults to TimestampFormatDate.
+	Format TimestampFormat
+}
+
+// TenantResolver returns the tenant of the operation from the provided context.
+type TenantResolver func(ctx context.Context) (string, error)
+
+// TenancyOptions are the options of the shared collection multi-tenancy.
+type TenancyOptions struct {
+	// Key is the bson key of the field holding the tenant. Defaults to tenantId.
+	Key string
+	// Resolver returns the tenant of the operation from the context. Required.
+	Resolver TenantResolver
+}
+
+// ResolveTenant returns the tenant of the operation from the provided context.
+// It returns [errors.ErrTenantNotFound] if the resolver is missing or the context doesn't hold a tenant.
+func (o TenancyOptions) ResolveTenant(ctx context.Context) (string, error) {
+	if o.Resolver == nil {
+		return "", errors.ErrTenantNotFound
+	}
+
+	tenant, err := o.Resolver(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errors.ErrTenantNotFound, err)
+	}
+
+	if tenant == "" {
+		return "", errors.ErrTenantNotFound
+	}
+
+	return tenant, nil
 }
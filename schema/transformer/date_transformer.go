@@ -1,8 +1,11 @@
 package transformer
 
 import (
+	"fmt"
 	"reflect"
+	"time"
 
+	"github.com/Lyearn/mgod/errors"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -13,26 +16,55 @@ func newDateTransformer() Transformer {
 }
 
 // DateTransformer is a transformer that converts a string in ISO format to primitive.DateTime and vice versa.
+// Values which are already in the required format are returned as it is. time.Time values are converted to primitive.DateTime.
 var DateTransformer = newDateTransformer()
 
 func (t dateTransformer) IsTransformationRequired(field reflect.StructField) bool {
-	return field.Tag.Get("mgoType") == "date"
+	return field.Tag.Get(TransformerTag) == "date"
+}
+
+func (t dateTransformer) GetBSONType() string {
+	return "date"
 }
 
 func (t dateTransformer) TransformForMongoDoc(value interface{}) (interface{}, error) {
-	primitiveDates, err := convertStringToDateTime(value.(string))
-	if err != nil {
-		return nil, err
-	}
+	switch typedValue := value.(type) {
+	case primitive.DateTime:
+		return typedValue, nil
+	case time.Time:
+		return primitive.NewDateTimeFromTime(typedValue), nil
+	case string:
+		primitiveDates, err := convertStringToDateTime(typedValue)
+		if err != nil {
+			return nil, err
+		}
 
-	return primitiveDates[0], nil
+		return primitiveDates[0], nil
+	default:
+		return nil, errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: "date transformer value",
+			Got:        fmt.Sprintf("%T", value),
+			Expected:   "string, time.Time or primitive.DateTime",
+		})
+	}
 }
 
 func (t dateTransformer) TransformForEntityModelDoc(value interface{}) (interface{}, error) {
-	dates, err := convertDateTimeToString(value.(primitive.DateTime))
-	if err != nil {
-		return nil, err
-	}
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue, nil
+	case primitive.DateTime:
+		dates, err := convertDateTimeToString(typedValue)
+		if err != nil {
+			return nil, err
+		}
 
-	return dates[0], nil
+		return dates[0], nil
+	default:
+		return nil, errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: "date transformer value",
+			Got:        fmt.Sprintf("%T", value),
+			Expected:   "string or primitive.DateTime",
+		})
+	}
 }
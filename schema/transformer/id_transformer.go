@@ -1,8 +1,10 @@
 package transformer
 
 import (
+	"fmt"
 	"reflect"
 
+	"github.com/Lyearn/mgod/errors"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -13,26 +15,53 @@ func newIDTransformer() Transformer {
 }
 
 // IDTransformer is a transformer that converts a string to primitive.ObjectID and vice versa.
+// Values which are already in the required format are returned as it is.
 var IDTransformer = newIDTransformer()
 
 func (t idTransformer) IsTransformationRequired(field reflect.StructField) bool {
-	return field.Tag.Get("mgoType") == "id"
+	return field.Tag.Get(TransformerTag) == "id"
+}
+
+func (t idTransformer) GetBSONType() string {
+	return "objectId"
 }
 
 func (t idTransformer) TransformForMongoDoc(value interface{}) (interface{}, error) {
-	objectIDs, err := convertStringToObjectID(value.(string))
-	if err != nil {
-		return nil, err
-	}
+	switch typedValue := value.(type) {
+	case primitive.ObjectID:
+		return typedValue, nil
+	case string:
+		objectIDs, err := convertStringToObjectID(typedValue)
+		if err != nil {
+			return nil, err
+		}
 
-	return objectIDs[0], nil
+		return objectIDs[0], nil
+	default:
+		return nil, errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: "id transformer value",
+			Got:        fmt.Sprintf("%T", value),
+			Expected:   "string or primitive.ObjectID",
+		})
+	}
 }
 
 func (t idTransformer) TransformForEntityModelDoc(value interface{}) (interface{}, error) {
-	ids, err := convertObjectIDToString(value.(primitive.ObjectID))
-	if err != nil {
-		return nil, err
-	}
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue, nil
+	case primitive.ObjectID:
+		ids, err := convertObjectIDToString(typedValue)
+		if err != nil {
+			return nil, err
+		}
 
-	return ids[0], nil
+		return ids[0], nil
+	default:
+		return nil, errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: "id transformer value",
+			Got:        fmt.Sprintf("%T", value),
+			Expected:   "string or primitive.ObjectID",
+		})
+	}
 }
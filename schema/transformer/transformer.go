@@ -1,11 +1,18 @@
 // Package transformer provides custom transformers for schema fields.
 package transformer
 
-import "reflect"
+import (
+	"reflect"
+	"sync"
+
+	"github.com/Lyearn/mgod/errors"
+	"github.com/samber/lo"
+)
 
 // Transformer can transform fields in both directions i.e. from entity model to mongo doc and vice versa.
 type Transformer interface {
 	// IsTransformationRequired reports whether the transformer is required for the given field.
+	// It is not used for the transformers added using [Register] as they are applied based on the registered name.
 	IsTransformationRequired(field reflect.StructField) bool
 	// TransformForMongoDoc transforms the incoming value according to mongo requirements.
 	TransformForMongoDoc(value interface{}) (interface{}, error)
@@ -13,11 +20,55 @@ type Transformer interface {
 	TransformForEntityModelDoc(value interface{}) (interface{}, error)
 }
 
+// BSONTypeProvider can be optionally implemented by a transformer to provide the bson type of the transformed value
+// (e.g. decimal, binData). It is used while generating the $jsonSchema validator of the entity model.
+type BSONTypeProvider interface {
+	GetBSONType() string
+}
+
+// TransformerTag is the tag used to declare the transformer of a field.
+const TransformerTag = "mgoType"
+
 var availableTransformers = []Transformer{
 	IDTransformer,
 	DateTransformer,
 }
 
+var (
+	registeredTransformersMu sync.RWMutex
+	// registeredTransformers are the user-defined transformers keyed by their name.
+	registeredTransformers = map[string]Transformer{}
+	// reservedTransformerNames are the names used by the available transformers.
+	reservedTransformerNames = []string{"id", "date"}
+)
+
+// Register adds a user-defined transformer which is applied on the fields with `mgoType:"<name>"`.
+// It returns an error if a transformer is already registered with the same name.
+// Transformers should be registered before building the schema of the models using them, usually in an init function.
+func Register(name string, transformer Transformer) error {
+	if name == "" || transformer == nil {
+		return errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: "transformer registration",
+			Got:        "empty name or nil transformer",
+			Expected:   "non empty name and transformer",
+		})
+	}
+
+	registeredTransformersMu.Lock()
+	defer registeredTransformersMu.Unlock()
+
+	if _, ok := registeredTransformers[name]; ok || lo.Contains(reservedTransformerNames, name) {
+		return errors.NewAlreadyExistsError(errors.AlreadyExistsError{
+			Underlying: "transformer registry",
+			Value:      name,
+		})
+	}
+
+	registeredTransformers[name] = transformer
+
+	return nil
+}
+
 // GetRequiredTransformersForField returns the transformers required for the given field.
 func GetRequiredTransformersForField(field reflect.StructField) []Transformer {
 	transformers := []Transformer{}
@@ -28,5 +79,12 @@ func GetRequiredTransformersForField(field reflect.StructField) []Transformer {
 		}
 	}
 
+	registeredTransformersMu.RLock()
+	defer registeredTransformersMu.RUnlock()
+
+	if transformer, ok := registeredTransformers[field.Tag.Get(TransformerTag)]; ok {
+		transformers = append(transformers, transformer)
+	}
+
 	return transformers
 }
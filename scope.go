@@ -0,0 +1,343 @@
+package mgod
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Lyearn/mgod/bsondoc"
+	"github.com/Lyearn/mgod/errors"
+	"github.com/Lyearn/mgod/schema/metafield"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// pipelineLeadingStages are the aggregation stages which must be the first stage of a pipeline.
+// Scope of the query is matched right after them.
+var pipelineLeadingStages = []string{"$geoNear", "$search", "$searchMeta", "$vectorSearch", "$collStats", "$indexStats"}
+
+// getTenantKey returns the key of the tenant meta field.
+func (m entityMongoModel[T]) getTenantKey() string {
+	return string(metafield.GetTenantKey(m.schemaOpts))
+}
+
+// getTenantFilter returns the filter restricting a query to the docs of the tenant resolved from the provided context.
+// It returns nil if the tenancy is disabled for the model.
+func (m entityMongoModel[T]) getTenantFilter(ctx context.Context) (bson.D, error) {
+	if m.schemaOpts.Tenancy == nil {
+		//nolint:nilnil // nil filter is a valid value
+		return nil, nil
+	}
+
+	tenant, err := m.schemaOpts.Tenancy.ResolveTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// tenant is translated in the same way as a filter so that the transformers of a declared tenant field are applied.
+	tenantFilter, err := bsondoc.BuildFilter(ctx, bson.D{{Key: m.getTenantKey(), Value: tenant}}, m.schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return tenantFilter.(bson.D), nil
+}
+
+// getScopeFilter returns the filter restricting a query to the docs in the scope of the provided context i.e. the docs
+// of the tenant which are not soft deleted (unless requested otherwise using the query options).
+// It returns nil if the query is not restricted.
+func (m entityMongoModel[T]) getScopeFilter(ctx context.Context) (bson.D, error) {
+	tenantFilter, err := m.getTenantFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	softDeleteFilter := m.getSoftDeleteFilter(ctx)
+	if tenantFilter == nil {
+		return softDeleteFilter, nil
+	}
+
+	return append(tenantFilter, softDeleteFilter...), nil
+}
+
+// restrictFilter restricts the provided (already built) filter using the provided scope filter.
+func restrictFilter(filter interface{}, scopeFilter bson.D) interface{} {
+	if scopeFilter == nil {
+		return filter
+	}
+
+	if filter == nil {
+		return scopeFilter
+	}
+
+	if scopedFilter, ok := addRootFields(filter, scopeFilter); ok {
+		return scopedFilter
+	}
+
+	return bson.D{{Key: "$and", Value: bson.A{filter, scopeFilter}}}
+}
+
+// addRootFields returns a copy of the provided filter with the provided fields added at the root level.
+// It reports false if the filter is not a document or already holds any of the fields.
+func addRootFields(filter interface{}, fields bson.D) (interface{}, bool) {
+	isFieldPresent := func(key string) bool {
+		return lo.ContainsBy(fields, func(field bson.E) bool { return field.Key == key })
+	}
+
+	switch typedFilter := filter.(type) {
+	case bson.D:
+		if lo.ContainsBy(typedFilter, func(elem bson.E) bool { return isFieldPresent(elem.Key) }) {
+			return nil, false
+		}
+
+		return append(append(bson.D{}, typedFilter...), fields...), true
+	case bson.M:
+		scopedFilter, ok := addMapFields(typedFilter, fields, isFieldPresent)
+		return bson.M(scopedFilter), ok
+	case map[string]interface{}:
+		return addMapFields(typedFilter, fields, isFieldPresent)
+	default:
+		return nil, false
+	}
+}
+
+func addMapFields(filter map[string]interface{}, fields bson.D, isFieldPresent func(key string) bool) (map[string]interface{}, bool) {
+	scopedFilter := make(map[string]interface{}, len(filter)+len(fields))
+
+	for key, value := range filter {
+		if isFieldPresent(key) {
+			return nil, false
+		}
+
+		scopedFilter[key] = value
+	}
+
+	for _, field := range fields {
+		scopedFilter[field.Key] = field.Value
+	}
+
+	return scopedFilter, true
+}
+
+// buildScopedFilter translates the provided filter query according to the entity model schema and restricts it to
+// the docs in the scope of the provided context. It is used by the read and the soft delete operations.
+func (m entityMongoModel[T]) buildScopedFilter(ctx context.Context, filter interface{}) (interface{}, error) {
+	filterQuery, err := m.buildFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	scopeFilter, err := m.getScopeFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return restrictFilter(filterQuery, scopeFilter), nil
+}
+
+// buildTenantFilter translates the provided filter query according to the entity model schema and restricts it to
+// the docs of the tenant resolved from the provided context. It is used by the write operations, which are not
+// restricted by the soft delete scope.
+func (m entityMongoModel[T]) buildTenantFilter(ctx context.Context, filter interface{}) (interface{}, error) {
+	filterQuery, err := m.buildFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantFilter, err := m.getTenantFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return restrictFilter(filterQuery, tenantFilter), nil
+}
+
+// addTenantToDoc adds the tenant resolved from the provided context to the provided doc to be written, which is not
+// built from an entity model (e.g. a bson.D doc generated using GetDocToInsert). A doc of any other tenant is rejected
+// with [errors.ErrCrossTenantWrite]. The provided doc is not modified, a copy is returned instead.
+func (m entityMongoModel[T]) addTenantToDoc(ctx context.Context, doc bson.D) (bson.D, error) {
+	tenantFilter, err := m.getTenantFilter(ctx)
+	if err != nil || tenantFilter == nil {
+		return doc, err
+	}
+
+	tenantElem := tenantFilter[0]
+
+	for _, elem := range doc {
+		if elem.Key != tenantElem.Key {
+			continue
+		}
+
+		if elem.Value != tenantElem.Value {
+			return nil, fmt.Errorf("%w: doc of tenant %v in the context of tenant %v",
+				errors.ErrCrossTenantWrite, elem.Value, tenantElem.Value)
+		}
+
+		return doc, nil
+	}
+
+	return append(append(bson.D{}, doc...), tenantElem), nil
+}
+
+// checkTenantUpdate rejects the (already built) update query with [errors.ErrCrossTenantWrite] if it modifies
+// the tenant of the docs. Setting the tenant resolved from the provided context is allowed.
+func (m entityMongoModel[T]) checkTenantUpdate(ctx context.Context, updateQuery bson.D) error {
+	if m.schemaOpts.Tenancy == nil {
+		return nil
+	}
+
+	tenant, err := m.schemaOpts.Tenancy.ResolveTenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	tenantKey := m.getTenantKey()
+	isTenantField := func(field string) bool {
+		return field == tenantKey || strings.HasPrefix(field, tenantKey+".")
+	}
+
+	crossTenantErr := func(operator string) error {
+		return fmt.Errorf("%w: %s update operator modifies %s in the context of tenant %s",
+			errors.ErrCrossTenantWrite, operator, tenantKey, tenant)
+	}
+
+	for _, operator := range updateQuery {
+		for _, elem := range toBSONDoc(operator.Value) {
+			if operator.Key == "$rename" && isTenantField(fmt.Sprint(elem.Value)) {
+				return crossTenantErr(operator.Key)
+			}
+
+			if !isTenantField(elem.Key) {
+				continue
+			}
+
+			isSetOperator := operator.Key == "$set" || operator.Key == "$setOnInsert"
+			if isSetOperator && elem.Key == tenantKey && elem.Value == tenant {
+				continue
+			}
+
+			return crossTenantErr(operator.Key)
+		}
+	}
+
+	return nil
+}
+
+// toBSONDoc returns the elements of the provided document. It returns nil if the value is not a document.
+func toBSONDoc(doc interface{}) bson.D {
+	switch typedDoc := doc.(type) {
+	case bson.D:
+		return typedDoc
+	case bson.M:
+		return lo.MapToSlice(typedDoc, func(key string, value interface{}) bson.E { return bson.E{Key: key, Value: value} })
+	case map[string]interface{}:
+		return lo.MapToSlice(typedDoc, func(key string, value interface{}) bson.E { return bson.E{Key: key, Value: value} })
+	default:
+		return nil
+	}
+}
+
+// scopePipeline restricts the provided aggregation pipeline to the docs in the scope of the provided context.
+// The scope is added to the first $match stage of the pipeline, or a new $match stage is added at the beginning.
+func (m entityMongoModel[T]) scopePipeline(ctx context.Context, pipeline interface{}) (interface{}, error) {
+	scopeFilter, err := m.getScopeFilter(ctx)
+	if err != nil {
+		return nil, err
+	} else if scopeFilter == nil {
+		return pipeline, nil
+	}
+
+	stages, err := toPipelineStages(pipeline, "aggregation pipeline")
+	if err != nil {
+		return nil, err
+	}
+
+	// stages are converted to bson.D to identify their operators.
+	for idx, stage := range stages {
+		stageDoc := toBSONDoc(stage)
+		if stageDoc == nil {
+			return nil, errors.NewBadRequestError(errors.BadRequestError{
+				Underlying: "aggregation pipeline stage",
+				Got:        fmt.Sprintf("%T", stage),
+				Expected:   "bson.D or bson.M",
+			})
+		}
+
+		stages[idx] = stageDoc
+	}
+
+	matchIdx := 0
+	if len(stages) > 0 && lo.Contains(pipelineLeadingStages, getStageOperator(stages[0])) {
+		matchIdx = 1
+	}
+
+	if matchIdx < len(stages) && getStageOperator(stages[matchIdx]) == "$match" {
+		matchStage, _ := stages[matchIdx].(bson.D)
+		stages[matchIdx] = bson.D{{Key: "$match", Value: bson.D{{Key: "$and", Value: bson.A{matchStage[0].Value, scopeFilter}}}}}
+
+		return stages, nil
+	}
+
+	stages = append(stages[:matchIdx], append(bson.A{bson.D{{Key: "$match", Value: scopeFilter}}}, stages[matchIdx:]...)...)
+
+	return stages, nil
+}
+
+// scopeChangeStreamPipeline restricts the provided change stream pipeline to the change events of the docs of the
+// tenant resolved from the provided context. The tenant is matched in the full doc of the event, or in the doc before
+// the change if the pre-images are requested (e.g. to receive the delete events).
+func (m entityMongoModel[T]) scopeChangeStreamPipeline(ctx context.Context, pipeline interface{}) (interface{}, error) {
+	tenantFilter, err := m.getTenantFilter(ctx)
+	if err != nil {
+		return nil, err
+	} else if tenantFilter == nil {
+		return pipeline, nil
+	}
+
+	stages, err := toPipelineStages(pipeline, "change stream pipeline")
+	if err != nil {
+		return nil, err
+	}
+
+	tenantMatch := bson.A{}
+	for _, docField := range []string{"fullDocument", "fullDocumentBeforeChange"} {
+		tenantMatch = append(tenantMatch, bson.D(lo.Map(tenantFilter, func(elem bson.E, _ int) bson.E {
+			return bson.E{Key: fmt.Sprintf("%s.%s", docField, elem.Key), Value: elem.Value}
+		})))
+	}
+
+	return append(bson.A{bson.D{{Key: "$match", Value: bson.D{{Key: "$or", Value: tenantMatch}}}}}, stages...), nil
+}
+
+// toPipelineStages returns a copy of the stages of the provided pipeline.
+func toPipelineStages(pipeline interface{}, underlying string) (bson.A, error) {
+	switch typedPipeline := pipeline.(type) {
+	case nil:
+		return bson.A{}, nil
+	case mongo.Pipeline:
+		return lo.Map(typedPipeline, func(stage bson.D, _ int) interface{} { return stage }), nil
+	case []bson.D:
+		return lo.Map(typedPipeline, func(stage bson.D, _ int) interface{} { return stage }), nil
+	case bson.A:
+		return append(bson.A{}, typedPipeline...), nil
+	case []interface{}:
+		return append(bson.A{}, typedPipeline...), nil
+	default:
+		return nil, errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: underlying,
+			Got:        fmt.Sprintf("%T", pipeline),
+			Expected:   "mongo.Pipeline, []bson.D or bson.A",
+		})
+	}
+}
+
+// getStageOperator returns the operator of the provided aggregation stage if it is a single key bson.D doc.
+func getStageOperator(stage interface{}) string {
+	stageDoc, ok := stage.(bson.D)
+	if !ok || len(stageDoc) != 1 {
+		return ""
+	}
+
+	return stageDoc[0].Key
+}
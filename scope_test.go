@@ -0,0 +1,138 @@
+package mgod_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lyearn/mgod"
+	"github.com/Lyearn/mgod/errors"
+	"github.com/Lyearn/mgod/schema/schemaopt"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type testTenantCtxKey struct{}
+
+type ScopeSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func TestScopeSuite(t *testing.T) {
+	s := new(ScopeSuite)
+	suite.Run(t, s)
+}
+
+func (s *ScopeSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *ScopeSuite) TestTenancy() {
+	schemaOpts := schemaopt.SchemaOptions{
+		Tenancy: &schemaopt.TenancyOptions{
+			Resolver: func(ctx context.Context) (string, error) {
+				tenant, _ := ctx.Value(testTenantCtxKey{}).(string)
+				return tenant, nil
+			},
+		},
+	}
+
+	entityMongoModel := newTestModel(s.T(), testEntity{}, "entityMongoModelTenancy", &schemaOpts)
+
+	tenant1Ctx := context.WithValue(context.Background(), testTenantCtxKey{}, "tenant1")
+	tenant2Ctx := context.WithValue(context.Background(), testTenantCtxKey{}, "tenant2")
+
+	_, err := entityMongoModel.InsertOne(context.Background(), testEntity{Name: "Gopher"})
+	s.ErrorIs(err, errors.ErrTenantNotFound)
+
+	for _, ctx := range []context.Context{tenant1Ctx, tenant2Ctx} {
+		_, err = entityMongoModel.DeleteMany(ctx, bson.M{})
+		s.NoError(err)
+
+		_, err = entityMongoModel.InsertOne(ctx, testEntity{Name: "Gopher"})
+		s.NoError(err)
+	}
+
+	entities, err := entityMongoModel.Find(tenant1Ctx, bson.M{"name": "Gopher"})
+	s.NoError(err)
+	s.Len(entities, 1)
+
+	result, err := entityMongoModel.UpdateMany(tenant1Ctx, bson.M{}, bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "Tenant1 Gopher"}}}})
+	s.NoError(err)
+	s.Equal(int64(1), result.ModifiedCount)
+
+	_, err = entityMongoModel.UpdateMany(tenant1Ctx, bson.M{}, bson.D{{Key: "$set", Value: bson.D{{Key: "tenantId", Value: "tenant2"}}}})
+	s.ErrorIs(err, errors.ErrCrossTenantWrite)
+
+	docs, err := entityMongoModel.Aggregate(tenant2Ctx, mongo.Pipeline{bson.D{{Key: "$match", Value: bson.M{"name": "Gopher"}}}})
+	s.NoError(err)
+	s.Len(docs, 1)
+	s.Equal("tenant2", lo.SliceToMap(docs[0], func(elem bson.E) (string, interface{}) { return elem.Key, elem.Value })["tenantId"])
+
+	count, err := entityMongoModel.CountDocuments(tenant2Ctx, bson.M{"name": "Tenant1 Gopher"})
+	s.NoError(err)
+	s.Equal(int64(0), count)
+
+	deleteResult, err := entityMongoModel.DeleteMany(tenant2Ctx, bson.M{})
+	s.NoError(err)
+	s.Equal(int64(1), deleteResult.DeletedCount)
+
+	count, err = entityMongoModel.CountDocuments(tenant1Ctx, bson.M{})
+	s.NoError(err)
+	s.Equal(int64(1), count)
+
+	_, err = entityMongoModel.Watch(context.Background(), mongo.Pipeline{})
+	s.ErrorIs(err, errors.ErrTenantNotFound)
+
+	stream, err := entityMongoModel.Watch(tenant1Ctx, mongo.Pipeline{})
+	s.NoError(err)
+
+	_, err = entityMongoModel.InsertOne(tenant2Ctx, testEntity{Name: "Watched Gopher"})
+	s.NoError(err)
+
+	tenant1Entity, err := entityMongoModel.InsertOne(tenant1Ctx, testEntity{Name: "Watched Gopher"})
+	s.NoError(err)
+
+	// change event of the other tenant is not returned.
+	s.True(stream.Next(tenant1Ctx))
+
+	event, err := stream.Decode()
+	s.NoError(err)
+	s.Equal("insert", event.OperationType)
+	s.Equal(tenant1Entity.ID, event.FullDocument.ID)
+	s.NoError(stream.Close(tenant1Ctx))
+}
+
+func (s *ScopeSuite) TestScopePipelineStages() {
+	clientName, err := registerTestClient("scope")
+	s.NoError(err)
+	defer func() { s.NoError(mgod.DisconnectClient(context.Background(), clientName)) }()
+
+	schemaOpts := schemaopt.SchemaOptions{SoftDelete: true}
+	entityMongoModel := newTestModelForClient(s.T(), clientName, testEntity{}, "scopePipeline", &schemaOpts)
+
+	geoNearStage := bson.M{"$geoNear": bson.M{"near": bson.A{0, 0}, "distanceField": "distance"}}
+	pipeline, err := mgod.ScopePipeline(context.Background(), entityMongoModel, bson.A{
+		geoNearStage,
+		bson.M{"$match": bson.M{"name": "Gopher"}},
+	})
+	s.NoError(err)
+
+	stages, ok := pipeline.(bson.A)
+	s.True(ok)
+	s.Len(stages, 2)
+	s.Equal(bson.D{{Key: "$geoNear", Value: geoNearStage["$geoNear"]}}, stages[0])
+
+	matchStage, ok := stages[1].(bson.D)
+	s.True(ok)
+	s.Equal("$match", matchStage[0].Key)
+	s.Contains(matchStage[0].Value.(bson.D)[0].Value, bson.M{"name": "Gopher"})
+
+	_, err = mgod.ScopePipeline(context.Background(), entityMongoModel, bson.A{"$geoNear"})
+
+	var badRequestErr errors.BadRequestError
+	s.ErrorAs(err, &badRequestErr)
+}
@@ -0,0 +1,50 @@
+package mgod
+
+import (
+	"context"
+
+	"github.com/Lyearn/mgod/schema"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// getDeselectedFields returns the field paths of the doc which are not selected by default
+// (see [fieldopt.SelectOption]) in the order of the schema tree.
+// Children of a deselected field are skipped as they are already excluded along with their parent.
+func getDeselectedFields(entityModelSchema *schema.EntityModelSchema) []string {
+	deselectedFields := []string{}
+
+	var collectFields func(nodes []schema.TreeNode)
+	collectFields = func(nodes []schema.TreeNode) {
+		for _, node := range nodes {
+			if isSelected := node.Props.Options.Select; isSelected != nil && !*isSelected {
+				deselectedFields = append(deselectedFields, schema.GetFieldPathFromSchemaPath(node.Path))
+				continue
+			}
+
+			collectFields(node.Children)
+		}
+	}
+
+	collectFields(entityModelSchema.Root.Children)
+
+	return deselectedFields
+}
+
+// getDefaultProjection returns the projection excluding the deselected fields of the entity model except the ones
+// explicitly selected using the Select query option (see [WithQueryOptions]).
+// It returns nil if no field needs to be excluded.
+func (m entityMongoModel[T]) getDefaultProjection(ctx context.Context) bson.D {
+	selectedFields := getQueryOptions(ctx).Select
+
+	var projection bson.D
+	for _, field := range getDeselectedFields(m.schema) {
+		if lo.Contains(selectedFields, field) {
+			continue
+		}
+
+		projection = append(projection, bson.E{Key: field, Value: 0})
+	}
+
+	return projection
+}
@@ -0,0 +1,57 @@
+package mgod_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lyearn/mgod"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SelectSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func TestSelectSuite(t *testing.T) {
+	s := new(SelectSuite)
+	suite.Run(t, s)
+}
+
+func (s *SelectSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *SelectSuite) TestFindWithDeselectedFields() {
+	type selectTestUser struct {
+		ID           string `bson:"_id" mgoType:"id"`
+		Name         string `bson:"name"`
+		PasswordHash string `bson:"passwordHash" mgoSelect:"false"`
+	}
+
+	userModel := newTestModel(s.T(), selectTestUser{}, "entityMongoModelSelect", nil)
+
+	user, err := userModel.InsertOne(context.Background(), selectTestUser{Name: "select", PasswordHash: "hash"})
+	s.NoError(err)
+
+	foundUser, err := userModel.FindOne(context.Background(), bson.M{"_id": user.ID})
+	s.NoError(err)
+	s.Equal("select", foundUser.Name)
+	s.Empty(foundUser.PasswordHash)
+
+	updatedUser, err := userModel.FindOneAndUpdate(context.Background(), bson.M{"_id": user.ID},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "selected"}}}}, options.FindOneAndUpdate().SetReturnDocument(options.After))
+	s.NoError(err)
+	s.Equal("selected", updatedUser.Name)
+	s.Empty(updatedUser.PasswordHash)
+
+	// deselected fields can be explicitly selected for a query.
+	ctx := mgod.WithQueryOptions(context.Background(), mgod.QueryOptions{Select: []string{"passwordHash"}})
+	users, err := userModel.Find(ctx, bson.M{"_id": user.ID})
+	s.NoError(err)
+	s.Len(users, 1)
+	s.Equal("hash", users[0].PasswordHash)
+}
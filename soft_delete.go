@@ -0,0 +1,254 @@
+package mgod
+
+import (
+	"context"
+
+	"github.com/Lyearn/mgod/errors"
+	"github.com/Lyearn/mgod/schema/metafield"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// getDeletedAtKey returns the key of the deleted at meta field.
+func (m entityMongoModel[T]) getDeletedAtKey() string {
+	return string(metafield.DeletedAtField.GetKey())
+}
+
+// getSoftDeleteFilter returns the filter restricting a query to the docs which are not soft deleted unless requested
+// otherwise using the query options. It returns nil if the query is not restricted.
+func (m entityMongoModel[T]) getSoftDeleteFilter(ctx context.Context) bson.D {
+	if !m.schemaOpts.SoftDelete {
+		return nil
+	}
+
+	queryOpts := getQueryOptions(ctx)
+
+	switch {
+	case queryOpts.OnlyDeleted:
+		return bson.D{{Key: m.getDeletedAtKey(), Value: bson.D{{Key: "$ne", Value: nil}}}}
+	case queryOpts.WithDeleted:
+		return nil
+	default:
+		return bson.D{{Key: m.getDeletedAtKey(), Value: nil}}
+	}
+}
+
+// getSoftDeleteUpdateQuery returns the update query setting the deleted at meta field of the docs.
+func (m entityMongoModel[T]) getSoftDeleteUpdateQuery(ctx context.Context, funcName string) (bson.D, error) {
+	return m.buildUpdateQuery(ctx, bson.D{
+		{Key: "$currentDate", Value: bson.D{{Key: m.getDeletedAtKey(), Value: true}}},
+	}, funcName)
+}
+
+// getSoftDeleteQueries returns the filter and the update query soft deleting the docs matching the provided filter
+// which are not deleted yet.
+func (m entityMongoModel[T]) getSoftDeleteQueries(ctx context.Context, filter interface{},
+	funcName string,
+) (interface{}, bson.D, error) {
+	filterQuery, err := m.buildScopedFilter(withSoftDeleteScope(ctx, false, false), filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updateQuery, err := m.getSoftDeleteUpdateQuery(ctx, funcName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return filterQuery, updateQuery, nil
+}
+
+// softDelete sets the deleted at meta field of the docs matching the provided filter which are not deleted yet.
+func (m entityMongoModel[T]) softDelete(ctx context.Context, filter interface{}, isMany bool, funcName string,
+	opts ...*options.DeleteOptions,
+) (*mongo.DeleteResult, error) {
+	ctx = withSoftDeleteScope(ctx, false, false)
+
+	filterQuery, err := m.buildScopedFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = runHooks(ctx, m.hooks, beforeDeleteHook, filterQuery); err != nil {
+		return nil, err
+	}
+
+	updateQuery, err := m.getSoftDeleteUpdateQuery(ctx, funcName)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteOpts := options.MergeDeleteOptions(opts...)
+	updateOpts := &options.UpdateOptions{
+		Collation: deleteOpts.Collation,
+		Comment:   deleteOpts.Comment,
+		Hint:      deleteOpts.Hint,
+		Let:       deleteOpts.Let,
+	}
+
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *mongo.UpdateResult
+	if isMany {
+		result, err = coll.UpdateMany(ctx, filterQuery, updateQuery, updateOpts)
+	} else {
+		result, err = coll.UpdateOne(ctx, filterQuery, updateQuery, updateOpts)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	deleteResult := &mongo.DeleteResult{DeletedCount: result.ModifiedCount}
+
+	if err = runHooks(ctx, m.hooks, afterDeleteHook, filterQuery); err != nil {
+		return deleteResult, err
+	}
+
+	return deleteResult, nil
+}
+
+// softFindOneAndDelete sets the deleted at meta field of a single doc matching the provided filter which is not
+// deleted yet, and returns the doc as it was before the deletion.
+func (m entityMongoModel[T]) softFindOneAndDelete(ctx context.Context, filter interface{},
+	opts ...*options.FindOneAndDeleteOptions,
+) (T, error) {
+	ctx = withSoftDeleteScope(ctx, false, false)
+
+	filterQuery, err := m.buildScopedFilter(ctx, filter)
+	if err != nil {
+		return m.getEntityModel(), err
+	}
+
+	if err = runHooks(ctx, m.hooks, beforeDeleteHook, filterQuery); err != nil {
+		return m.getEntityModel(), err
+	}
+
+	updateQuery, err := m.getSoftDeleteUpdateQuery(ctx, "FindOneAndDelete")
+	if err != nil {
+		return m.getEntityModel(), err
+	}
+
+	deleteOpts := options.MergeFindOneAndDeleteOptions(opts...)
+	updateOpts := &options.FindOneAndUpdateOptions{
+		Collation:  deleteOpts.Collation,
+		Comment:    deleteOpts.Comment,
+		MaxTime:    deleteOpts.MaxTime,
+		Projection: deleteOpts.Projection,
+		Sort:       deleteOpts.Sort,
+		Hint:       deleteOpts.Hint,
+		Let:        deleteOpts.Let,
+	}
+
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return m.getEntityModel(), err
+	}
+
+	cursor := coll.FindOneAndUpdate(ctx, filterQuery, updateQuery, updateOpts)
+
+	model, err := m.decodeSingleResult(ctx, cursor)
+	if err != nil {
+		return model, err
+	}
+
+	if err = runHooks(ctx, m.hooks, afterDeleteHook, filterQuery); err != nil {
+		return model, err
+	}
+
+	return model, nil
+}
+
+func (m entityMongoModel[T]) Restore(ctx context.Context, filter interface{},
+	opts ...*options.UpdateOptions,
+) (_ *mongo.UpdateResult, err error) {
+	defer wrapOperationError("Restore", &err)
+
+	if !m.schemaOpts.SoftDelete {
+		return nil, errors.NewBadRequestError(errors.BadRequestError{
+			Underlying: "restore",
+			Got:        "soft delete disabled",
+			Expected:   "soft delete enabled schema option",
+		})
+	}
+
+	ctx = withSoftDeleteScope(ctx, false, true)
+
+	filterQuery, err := m.buildScopedFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	updateQuery, err := m.buildUpdateQuery(ctx, bson.D{
+		{Key: "$unset", Value: bson.D{{Key: m.getDeletedAtKey(), Value: ""}}},
+	}, "Restore")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = runHooks(ctx, m.hooks, beforeUpdateHook(filterQuery), &updateQuery); err != nil {
+		return nil, err
+	}
+
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := coll.UpdateMany(ctx, filterQuery, updateQuery, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = runHooks(ctx, m.hooks, afterUpdateHook(filterQuery), updateQuery); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (m entityMongoModel[T]) HardDelete(ctx context.Context, filter interface{},
+	opts ...*options.DeleteOptions,
+) (_ *mongo.DeleteResult, err error) {
+	defer wrapOperationError("HardDelete", &err)
+
+	ctx = withSoftDeleteScope(ctx, true, false)
+
+	filterQuery, err := m.buildScopedFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = runHooks(ctx, m.hooks, beforeDeleteHook, filterQuery); err != nil {
+		return nil, err
+	}
+
+	coll, err := m.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := coll.DeleteMany(ctx, filterQuery, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = runHooks(ctx, m.hooks, afterDeleteHook, filterQuery); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// withSoftDeleteScope returns a copy of the provided context with the soft delete related query options overridden.
+func withSoftDeleteScope(ctx context.Context, withDeleted, onlyDeleted bool) context.Context {
+	queryOpts := getQueryOptions(ctx)
+	queryOpts.WithDeleted = withDeleted
+	queryOpts.OnlyDeleted = onlyDeleted
+
+	return WithQueryOptions(ctx, queryOpts)
+}
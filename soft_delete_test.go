@@ -0,0 +1,81 @@
+package mgod_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lyearn/mgod"
+	"github.com/Lyearn/mgod/schema/schemaopt"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type SoftDeleteSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func TestSoftDeleteSuite(t *testing.T) {
+	s := new(SoftDeleteSuite)
+	suite.Run(t, s)
+}
+
+func (s *SoftDeleteSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *SoftDeleteSuite) TestSoftDelete() {
+	schemaOpts := schemaopt.SchemaOptions{SoftDelete: true}
+
+	entityMongoModel := newTestModel(s.T(), testEntity{}, "entityMongoModelSoftDelete", &schemaOpts)
+
+	_, err := entityMongoModel.HardDelete(context.Background(), bson.M{})
+	s.NoError(err)
+
+	_, err = entityMongoModel.InsertMany(context.Background(), []testEntity{{Name: "active"}, {Name: "deleted"}})
+	s.NoError(err)
+
+	result, err := entityMongoModel.DeleteOne(context.Background(), bson.M{"name": "deleted"})
+	s.NoError(err)
+	s.Equal(int64(1), result.DeletedCount)
+
+	entities, err := entityMongoModel.Find(context.Background(), bson.M{})
+	s.NoError(err)
+	s.Len(entities, 1)
+	s.Equal("active", entities[0].Name)
+
+	count, err := entityMongoModel.CountDocuments(context.Background(), bson.M{"name": "deleted"})
+	s.NoError(err)
+	s.Equal(int64(0), count)
+
+	docs, err := entityMongoModel.Aggregate(context.Background(), mongo.Pipeline{})
+	s.NoError(err)
+	s.Len(docs, 1)
+
+	ctx := mgod.WithQueryOptions(context.Background(), mgod.QueryOptions{WithDeleted: true})
+	entities, err = entityMongoModel.Find(ctx, bson.M{})
+	s.NoError(err)
+	s.Len(entities, 2)
+
+	ctx = mgod.WithQueryOptions(context.Background(), mgod.QueryOptions{OnlyDeleted: true})
+	deletedEntity, err := entityMongoModel.FindOne(ctx, bson.M{})
+	s.NoError(err)
+	s.Equal("deleted", deletedEntity.Name)
+
+	restoreResult, err := entityMongoModel.Restore(context.Background(), bson.M{"name": "deleted"})
+	s.NoError(err)
+	s.Equal(int64(1), restoreResult.ModifiedCount)
+
+	count, err = entityMongoModel.CountDocuments(context.Background(), bson.M{})
+	s.NoError(err)
+	s.Equal(int64(2), count)
+
+	_, err = entityMongoModel.DeleteMany(context.Background(), bson.M{})
+	s.NoError(err)
+
+	result, err = entityMongoModel.HardDelete(context.Background(), bson.M{})
+	s.NoError(err)
+	s.Equal(int64(2), result.DeletedCount)
+}
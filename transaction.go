@@ -2,30 +2,235 @@ package mgod
 
 import (
 	"context"
+	goerrors "errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
+const (
+	transientTransactionErrLabel        = "TransientTransactionError"
+	unknownTransactionCommitResultLabel = "UnknownTransactionCommitResult"
+)
+
+// defaultTransactionMaxRetries is the default number of retries of a transaction (or its commit) failing with
+// a transient error.
+const defaultTransactionMaxRetries = 3
+
 // TransactionFunc is the function that is executed in a MongoDB transaction.
 //
 // SessionContext(sc) combines the context.Context and mongo.Session interfaces.
 type TransactionFunc func(sc mongo.SessionContext) (interface{}, error)
 
-// WithTransaction executes the given transaction function with a new session.
+// TransactionOptions are the options available for a transaction started using WithTransactionT.
+type TransactionOptions struct {
+	clientName    string
+	readConcern   *readconcern.ReadConcern
+	writeConcern  *writeconcern.WriteConcern
+	maxCommitTime *time.Duration
+	maxRetries    *int
+}
+
+// NewTransactionOptions creates a new TransactionOptions instance.
+func NewTransactionOptions() *TransactionOptions {
+	return &TransactionOptions{}
+}
+
+// SetClientName sets the name of the client (registered using [RegisterClient]) to start the session on.
+// Defaults to [DefaultClientName]. Models used in the transaction must use the same client.
+func (o *TransactionOptions) SetClientName(clientName string) *TransactionOptions {
+	o.clientName = clientName
+	return o
+}
+
+// SetReadConcern sets the read concern of the transaction. Defaults to the read concern of the client.
+func (o *TransactionOptions) SetReadConcern(rc *readconcern.ReadConcern) *TransactionOptions {
+	o.readConcern = rc
+	return o
+}
+
+// SetWriteConcern sets the write concern of the transaction. Defaults to the write concern of the client.
+func (o *TransactionOptions) SetWriteConcern(wc *writeconcern.WriteConcern) *TransactionOptions {
+	o.writeConcern = wc
+	return o
+}
+
+// SetMaxCommitTime sets the maximum amount of time the commit of the transaction is allowed to run on the server.
+func (o *TransactionOptions) SetMaxCommitTime(maxCommitTime time.Duration) *TransactionOptions {
+	o.maxCommitTime = &maxCommitTime
+	return o
+}
+
+// SetMaxRetries sets the number of times the transaction is retried if it fails with a TransientTransactionError,
+// and the number of times the commit is retried if its result is unknown. Defaults to 3.
+func (o *TransactionOptions) SetMaxRetries(maxRetries int) *TransactionOptions {
+	o.maxRetries = &maxRetries
+	return o
+}
+
+// mergeTransactionOptions merges the provided options. Later options override the earlier ones.
+func mergeTransactionOptions(opts ...*TransactionOptions) *TransactionOptions {
+	merged := &TransactionOptions{clientName: DefaultClientName}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		if opt.clientName != "" {
+			merged.clientName = opt.clientName
+		}
+
+		if opt.readConcern != nil {
+			merged.readConcern = opt.readConcern
+		}
+
+		if opt.writeConcern != nil {
+			merged.writeConcern = opt.writeConcern
+		}
+
+		if opt.maxCommitTime != nil {
+			merged.maxCommitTime = opt.maxCommitTime
+		}
+
+		if opt.maxRetries != nil {
+			merged.maxRetries = opt.maxRetries
+		}
+	}
+
+	return merged
+}
+
+// getDriverOptions returns the options of the underlying MongoDB transaction.
+func (o *TransactionOptions) getDriverOptions() *options.TransactionOptions {
+	// Reason behind using read preference:
+	// https://www.mongodb.com/community/forums/t/why-can-t-read-preference-be-secondary-in-a-transaction/204432
+	txnOpts := options.Transaction().SetReadPreference(readpref.Primary())
+
+	if o.readConcern != nil {
+		txnOpts.SetReadConcern(o.readConcern)
+	}
+
+	if o.writeConcern != nil {
+		txnOpts.SetWriteConcern(o.writeConcern)
+	}
+
+	if o.maxCommitTime != nil {
+		txnOpts.SetMaxCommitTime(o.maxCommitTime)
+	}
+
+	return txnOpts
+}
+
+func (o *TransactionOptions) getMaxRetries() int {
+	if o.maxRetries == nil {
+		return defaultTransactionMaxRetries
+	}
+
+	return *o.maxRetries
+}
+
+// WithTransaction executes the given transaction function with a new session of the default client.
+// See [WithTransactionT] for more details.
 func WithTransaction(ctx context.Context, transactionFunc TransactionFunc) (interface{}, error) {
-	session, err := mClient.StartSession()
+	return WithTransactionT[interface{}](ctx, transactionFunc)
+}
+
+// WithClientTransaction executes the given transaction function with a new session of the provided client
+// (registered using [RegisterClient]). Models used in the transaction must use the same client.
+func WithClientTransaction(ctx context.Context, clientName string, transactionFunc TransactionFunc) (interface{}, error) {
+	return WithTransactionT[interface{}](ctx, transactionFunc, NewTransactionOptions().SetClientName(clientName))
+}
+
+// WithTransactionT executes the given transaction function in a transaction and returns its typed result.
+//
+// If the provided context already holds a session of the same client (i.e. it is a SessionContext of an outer
+// transaction), the function joins it instead of starting a new session, and the options are ignored. The outer
+// transaction is then aborted or committed as a whole.
+//
+// Otherwise, a new session is started. The transaction is retried if it fails with a TransientTransactionError and
+// its commit is retried if the result is unknown, as per the retry budget in the options.
+func WithTransactionT[R any](ctx context.Context, transactionFunc func(sc mongo.SessionContext) (R, error),
+	opts ...*TransactionOptions,
+) (R, error) {
+	var result R
+
+	txnOpts := mergeTransactionOptions(opts...)
+
+	client, err := getClient(txnOpts.clientName)
+	if err != nil {
+		return result, err
+	}
+
+	if session := mongo.SessionFromContext(ctx); session != nil && session.Client() == client {
+		return transactionFunc(mongo.NewSessionContext(ctx, session))
+	}
+
+	session, err := client.StartSession()
 	if err != nil {
-		return nil, err
+		return result, err
 	}
 	defer session.EndSession(ctx)
 
-	// Reason behind using read preference:
-	// https://www.mongodb.com/community/forums/t/why-can-t-read-preference-be-secondary-in-a-transaction/204432
-	payload, transactionErr := session.WithTransaction(ctx, transactionFunc, &options.TransactionOptions{
-		ReadPreference: readpref.Primary(),
-	})
+	return runTransaction(mongo.NewSessionContext(ctx, session), transactionFunc, txnOpts)
+}
+
+// runTransaction runs the provided transaction function in a transaction of the session of the provided context.
+func runTransaction[R any](sc mongo.SessionContext, transactionFunc func(sc mongo.SessionContext) (R, error),
+	txnOpts *TransactionOptions,
+) (R, error) {
+	maxRetries := txnOpts.getMaxRetries()
+
+	for attempt := 0; ; attempt++ {
+		if err := sc.StartTransaction(txnOpts.getDriverOptions()); err != nil {
+			var result R
+			return result, err
+		}
+
+		result, err := transactionFunc(sc)
+		if err != nil {
+			// abort can fail if the transaction is already aborted by the server. the original error is returned anyway.
+			_ = sc.AbortTransaction(sc)
+
+			if hasErrorLabel(err, transientTransactionErrLabel) && attempt < maxRetries {
+				continue
+			}
+
+			return result, err
+		}
+
+		err = commitTransaction(sc, maxRetries)
+		if err != nil && hasErrorLabel(err, transientTransactionErrLabel) && attempt < maxRetries {
+			continue
+		}
+
+		return result, err
+	}
+}
+
+// commitTransaction commits the transaction of the provided session, retrying the commit if its result is unknown.
+func commitTransaction(sc mongo.SessionContext, maxRetries int) error {
+	for attempt := 0; ; attempt++ {
+		err := sc.CommitTransaction(sc)
+		if err == nil {
+			return nil
+		}
+
+		var cmdErr mongo.CommandError
+		isMaxTimeExpired := goerrors.As(err, &cmdErr) && cmdErr.IsMaxTimeMSExpiredError()
+
+		if !hasErrorLabel(err, unknownTransactionCommitResultLabel) || isMaxTimeExpired || attempt >= maxRetries {
+			return err
+		}
+	}
+}
 
-	return payload, transactionErr
+// hasErrorLabel reports whether the provided error (or any error wrapped by it) has the provided label.
+func hasErrorLabel(err error, label string) bool {
+	var labeledErr mongo.LabeledError
+	return goerrors.As(err, &labeledErr) && labeledErr.HasErrorLabel(label)
 }
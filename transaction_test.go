@@ -13,7 +13,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 type TransactionSuite struct {
@@ -32,24 +32,13 @@ func TestTransactionSuite(t *testing.T) {
 }
 
 func (s *TransactionSuite) SetupSuite() {
-	s.setupConnection()
+	connectTestClient(s.T())
 }
 
 func (s *TransactionSuite) SetupTest() {
 	s.Assertions = require.New(s.T())
 }
 
-func (s *TransactionSuite) setupConnection() {
-	cfg := &mgod.ConnectionConfig{Timeout: 5 * time.Second}
-	// Can use the `mlaunch` tool to start a local replica set using command `mlaunch --repl`.
-	uri := "mongodb://localhost:27017/?replicaSet=replset&authSource=admin"
-
-	err := mgod.ConfigureDefaultClient(cfg, options.Client().ApplyURI(uri))
-	if err != nil {
-		s.T().Fatal(err)
-	}
-}
-
 func (s *TransactionSuite) getModelForDB(dbName string) mgod.EntityMongoModel[transactionTestUser] {
 	schemaOpts := schemaopt.SchemaOptions{Timestamps: true}
 	opts := mgod.NewEntityMongoModelOptions(dbName, "users", &schemaOpts)
@@ -157,3 +146,61 @@ func (s *TransactionSuite) TestWithTransactionForMultiTenancy() {
 	s.True(ok)
 	s.Equal(userCountStr, "11")
 }
+
+func (s *TransactionSuite) TestWithTransactionT() {
+	userModel := s.getModelForDB("mgod1")
+	userDoc := transactionTestUser{Name: "Gopher", EmailID: "gopher@mgod.com"}
+
+	opts := mgod.NewTransactionOptions().
+		SetWriteConcern(writeconcern.New(writeconcern.WMajority())).
+		SetMaxCommitTime(5 * time.Second).
+		SetMaxRetries(1)
+
+	userCount, err := mgod.WithTransactionT(context.Background(), func(sc mongo.SessionContext) (int64, error) {
+		if _, err := userModel.InsertOne(sc, userDoc); err != nil {
+			return 0, err
+		}
+
+		userCount, err := userModel.CountDocuments(sc, bson.M{})
+		if err != nil {
+			return 0, err
+		}
+
+		if _, err = userModel.DeleteOne(sc, bson.M{}); err != nil {
+			return 0, err
+		}
+
+		return userCount, nil
+	}, opts)
+
+	s.NoError(err)
+	s.Equal(int64(1), userCount)
+}
+
+func (s *TransactionSuite) TestWithTransactionTNested() {
+	userModel := s.getModelForDB("mgod1")
+	userDoc := transactionTestUser{Name: "Gopher", EmailID: "gopher@mgod.com"}
+
+	abortErr := errors.New("dummy error to abort transaction")
+
+	insertUser := func(ctx context.Context) (transactionTestUser, error) {
+		return mgod.WithTransactionT(ctx, func(sc mongo.SessionContext) (transactionTestUser, error) {
+			return userModel.InsertOne(sc, userDoc)
+		})
+	}
+
+	_, err := mgod.WithTransactionT(context.Background(), func(sc mongo.SessionContext) (bool, error) {
+		if _, err := insertUser(sc); err != nil {
+			return false, err
+		}
+
+		return false, abortErr
+	})
+
+	s.ErrorIs(err, abortErr)
+
+	// nested transaction joined the outer one, so the insert is aborted as well.
+	userCount, err := userModel.CountDocuments(context.Background(), bson.M{})
+	s.NoError(err)
+	s.Equal(int64(0), userCount)
+}
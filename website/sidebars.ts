@@ -27,6 +27,8 @@ const sidebars: SidebarsConfig = {
         'field_options',
         'field_transformers',
         'meta_fields',
+        'hooks',
+        'errors',
       ],
       collapsed: false,
     },
@@ -34,9 +36,13 @@ const sidebars: SidebarsConfig = {
       type: 'category',
       label: 'Advanced Guide',
       items: [
+        'connection_management',
         'multi_tenancy',
         'union_types',
         'transactions',
+        'change_streams',
+        'json_schema_validation',
+        'references',
       ],
       collapsed: false,
     },